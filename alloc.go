@@ -0,0 +1,61 @@
+package forklift
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// AllocSite is an expression that commonly allocates on the heap.
+type AllocSite struct {
+	// Kind is "make", "new", "composite", "append", or "closure".
+	Kind string
+
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+
+	// InLoop is whether the expression is in the body of a for statement.
+	InLoop bool
+}
+
+// FindAllocationHotspots returns the expressions in p that commonly allocate on the heap:
+// calls to make, new, and append, composite literals, and function literals.
+// It requires syntax and types info.
+func FindAllocationHotspots(p *packages.Package) ([]AllocSite, error) {
+	if err := need(p, packages.NeedSyntax|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	var sites []AllocSite
+	for _, f := range p.Syntax {
+		inspectLoops(f, func(n ast.Node, inLoop bool) {
+			var kind string
+			switch n := n.(type) {
+			case *ast.CallExpr:
+				id, ok := ast.Unparen(n.Fun).(*ast.Ident)
+				if !ok {
+					return
+				}
+				if b, ok := p.TypesInfo.Uses[id].(*types.Builtin); ok {
+					switch b.Name() {
+					case "append", "make", "new":
+						kind = b.Name()
+					}
+				}
+			case *ast.CompositeLit:
+				kind = "composite"
+			case *ast.FuncLit:
+				kind = "closure"
+			}
+			if kind == "" {
+				return
+			}
+			file, line := position(p, n.Pos())
+			sites = append(sites, AllocSite{Kind: kind, File: file, Line: line, InLoop: inLoop})
+		})
+	}
+	return sites, nil
+}
@@ -0,0 +1,24 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindAllocationHotspots(t *testing.T) {
+	t.Parallel()
+	sites, err := FindAllocationHotspots(loadTestdata(t, "alloc"))
+	require.NoError(t, err)
+	for i := range sites {
+		sites[i].File = ""
+	}
+	assert.Equal(t, []AllocSite{
+		{Kind: "make", Line: 6},
+		{Kind: "append", Line: 8, InLoop: true},
+		{Kind: "closure", Line: 9, InLoop: true},
+		{Kind: "new", Line: 9},
+		{Kind: "composite", Line: 11},
+	}, sites)
+}
@@ -0,0 +1,227 @@
+package forklift
+
+import (
+	"fmt"
+	"go/types"
+	"reflect"
+	"sort"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/appends"
+	"golang.org/x/tools/go/analysis/passes/asmdecl"
+	"golang.org/x/tools/go/analysis/passes/assign"
+	"golang.org/x/tools/go/analysis/passes/atomic"
+	"golang.org/x/tools/go/analysis/passes/bools"
+	"golang.org/x/tools/go/analysis/passes/buildtag"
+	"golang.org/x/tools/go/analysis/passes/cgocall"
+	"golang.org/x/tools/go/analysis/passes/composite"
+	"golang.org/x/tools/go/analysis/passes/copylock"
+	"golang.org/x/tools/go/analysis/passes/defers"
+	"golang.org/x/tools/go/analysis/passes/directive"
+	"golang.org/x/tools/go/analysis/passes/errorsas"
+	"golang.org/x/tools/go/analysis/passes/framepointer"
+	"golang.org/x/tools/go/analysis/passes/httpresponse"
+	"golang.org/x/tools/go/analysis/passes/ifaceassert"
+	"golang.org/x/tools/go/analysis/passes/loopclosure"
+	"golang.org/x/tools/go/analysis/passes/lostcancel"
+	"golang.org/x/tools/go/analysis/passes/nilfunc"
+	"golang.org/x/tools/go/analysis/passes/printf"
+	"golang.org/x/tools/go/analysis/passes/shift"
+	"golang.org/x/tools/go/analysis/passes/sigchanyzer"
+	"golang.org/x/tools/go/analysis/passes/slog"
+	"golang.org/x/tools/go/analysis/passes/stdmethods"
+	"golang.org/x/tools/go/analysis/passes/stringintconv"
+	"golang.org/x/tools/go/analysis/passes/structtag"
+	"golang.org/x/tools/go/analysis/passes/testinggoroutine"
+	"golang.org/x/tools/go/analysis/passes/tests"
+	"golang.org/x/tools/go/analysis/passes/timeformat"
+	"golang.org/x/tools/go/analysis/passes/unmarshal"
+	"golang.org/x/tools/go/analysis/passes/unreachable"
+	"golang.org/x/tools/go/analysis/passes/unsafeptr"
+	"golang.org/x/tools/go/analysis/passes/unusedresult"
+	"golang.org/x/tools/go/packages"
+)
+
+// vetAnalyzers is the analyzers run by "go vet".
+var vetAnalyzers = []*analysis.Analyzer{
+	appends.Analyzer,
+	asmdecl.Analyzer,
+	assign.Analyzer,
+	atomic.Analyzer,
+	bools.Analyzer,
+	buildtag.Analyzer,
+	cgocall.Analyzer,
+	composite.Analyzer,
+	copylock.Analyzer,
+	defers.Analyzer,
+	directive.Analyzer,
+	errorsas.Analyzer,
+	framepointer.Analyzer,
+	httpresponse.Analyzer,
+	ifaceassert.Analyzer,
+	loopclosure.Analyzer,
+	lostcancel.Analyzer,
+	nilfunc.Analyzer,
+	printf.Analyzer,
+	shift.Analyzer,
+	sigchanyzer.Analyzer,
+	slog.Analyzer,
+	stdmethods.Analyzer,
+	stringintconv.Analyzer,
+	structtag.Analyzer,
+	testinggoroutine.Analyzer,
+	tests.Analyzer,
+	timeformat.Analyzer,
+	unmarshal.Analyzer,
+	unreachable.Analyzer,
+	unsafeptr.Analyzer,
+	unusedresult.Analyzer,
+}
+
+// Diagnostic is an analyzer report.
+type Diagnostic struct {
+	// Category is the diagnostic category, or the analyzer name if the diagnostic has none.
+	Category string
+
+	// Message is the diagnostic message.
+	Message string
+
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+
+	// Col is the column number.
+	Col int
+}
+
+// RunGoVet returns the diagnostics of the "go vet" analyzers for p, sorted by position.
+// Facts about dependencies are not available, so diagnostics that depend on them are not reported.
+// It requires syntax, types, and types info.
+func RunGoVet(p *packages.Package) ([]Diagnostic, error) {
+	ds, err := RunAnalyzers(p, vetAnalyzers...)
+	if err != nil {
+		return nil, err
+	}
+	diagnostics := make([]Diagnostic, len(ds))
+	for i, d := range ds {
+		pos := p.Fset.Position(d.Pos)
+		diagnostics[i] = Diagnostic{Category: d.Category, Message: d.Message, File: pos.Filename, Line: pos.Line, Col: pos.Column}
+	}
+	return diagnostics, nil
+}
+
+// factKey identifies a fact about an object or a package.
+type factKey struct {
+	obj types.Object
+	pkg *types.Package
+	typ reflect.Type
+}
+
+// RunAnalyzers returns the diagnostics of analyzers for p, sorted by position.
+// Required analyzers are run first, but their diagnostics are not returned.
+// Diagnostics without a category have the analyzer name.
+// Facts about dependencies are not available.
+// It requires syntax, types, and types info.
+func RunAnalyzers(p *packages.Package, analyzers ...*analysis.Analyzer) ([]*analysis.Diagnostic, error) {
+	if err := need(p, packages.NeedSyntax|packages.NeedTypes|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	roots := map[*analysis.Analyzer]bool{}
+	for _, a := range analyzers {
+		roots[a] = true
+	}
+	facts := map[factKey]analysis.Fact{}
+	results := map[*analysis.Analyzer]interface{}{}
+	done := map[*analysis.Analyzer]bool{}
+	var diagnostics []*analysis.Diagnostic
+	var run func(a *analysis.Analyzer) error
+	run = func(a *analysis.Analyzer) error {
+		if done[a] {
+			return nil
+		}
+		done[a] = true
+		resultOf := map[*analysis.Analyzer]interface{}{}
+		for _, r := range a.Requires {
+			if err := run(r); err != nil {
+				return err
+			}
+			resultOf[r] = results[r]
+		}
+		pass := &analysis.Pass{
+			Analyzer:     a,
+			Fset:         p.Fset,
+			Files:        p.Syntax,
+			OtherFiles:   p.OtherFiles,
+			IgnoredFiles: p.IgnoredFiles,
+			Pkg:          p.Types,
+			TypesInfo:    p.TypesInfo,
+			TypesSizes:   p.TypesSizes,
+			TypeErrors:   p.TypeErrors,
+			ResultOf:     resultOf,
+			Report: func(d analysis.Diagnostic) {
+				if !roots[a] {
+					return
+				}
+				if d.Category == "" {
+					d.Category = a.Name
+				}
+				diagnostics = append(diagnostics, &d)
+			},
+			ImportObjectFact: func(obj types.Object, fact analysis.Fact) bool {
+				return importFact(facts, factKey{obj: obj, typ: reflect.TypeOf(fact)}, fact)
+			},
+			ImportPackageFact: func(pkg *types.Package, fact analysis.Fact) bool {
+				return importFact(facts, factKey{pkg: pkg, typ: reflect.TypeOf(fact)}, fact)
+			},
+			ExportObjectFact: func(obj types.Object, fact analysis.Fact) {
+				facts[factKey{obj: obj, typ: reflect.TypeOf(fact)}] = fact
+			},
+			ExportPackageFact: func(fact analysis.Fact) {
+				facts[factKey{pkg: p.Types, typ: reflect.TypeOf(fact)}] = fact
+			},
+			AllObjectFacts: func() []analysis.ObjectFact {
+				var fs []analysis.ObjectFact
+				for k, f := range facts {
+					if k.obj != nil {
+						fs = append(fs, analysis.ObjectFact{Object: k.obj, Fact: f})
+					}
+				}
+				return fs
+			},
+			AllPackageFacts: func() []analysis.PackageFact {
+				var fs []analysis.PackageFact
+				for k, f := range facts {
+					if k.pkg != nil {
+						fs = append(fs, analysis.PackageFact{Package: k.pkg, Fact: f})
+					}
+				}
+				return fs
+			},
+		}
+		result, err := a.Run(pass)
+		if err != nil {
+			return fmt.Errorf("cannot run analyzer %s: %v", a.Name, err)
+		}
+		results[a] = result
+		return nil
+	}
+	for _, a := range analyzers {
+		if err := run(a); err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(diagnostics, func(i, j int) bool { return diagnostics[i].Pos < diagnostics[j].Pos })
+	return diagnostics, nil
+}
+
+// importFact copies the fact for k into fact and returns true if it exists, or returns false otherwise.
+func importFact(facts map[factKey]analysis.Fact, k factKey, fact analysis.Fact) bool {
+	f, ok := facts[k]
+	if !ok {
+		return false
+	}
+	reflect.ValueOf(fact).Elem().Set(reflect.ValueOf(f).Elem())
+	return true
+}
@@ -0,0 +1,62 @@
+package forklift
+
+import (
+	"errors"
+	"go/ast"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/assign"
+	inspectpass "golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+func TestRunGoVet(t *testing.T) {
+	t.Parallel()
+	diagnostics, err := RunGoVet(loadTestdata(t, "vet"))
+	require.NoError(t, err)
+	for i := range diagnostics {
+		diagnostics[i].File = ""
+	}
+	assert.Equal(t, []Diagnostic{
+		{Category: "printf", Message: "fmt.Printf format %s has arg n of wrong type int", Line: 6, Col: 2},
+		{Category: "assign", Message: "self-assignment of n to n", Line: 7, Col: 2},
+		{Category: "unreachable", Message: "unreachable code", Line: 9, Col: 2},
+		{Category: "structtag", Message: "struct field tag `json:name` not compatible with reflect.StructTag.Get: bad syntax for struct tag value", Line: 14, Col: 2},
+	}, diagnostics)
+	diagnostics, err = RunGoVet(loadTestdata(t, "typegraph"))
+	require.NoError(t, err)
+	assert.Empty(t, diagnostics)
+}
+
+func TestRunAnalyzers(t *testing.T) {
+	t.Parallel()
+	funcs := &analysis.Analyzer{
+		Name:     "funcs",
+		Doc:      "report function declarations",
+		Requires: []*analysis.Analyzer{inspectpass.Analyzer},
+		Run: func(pass *analysis.Pass) (interface{}, error) {
+			in := pass.ResultOf[inspectpass.Analyzer].(*inspector.Inspector)
+			in.Preorder([]ast.Node{(*ast.FuncDecl)(nil)}, func(n ast.Node) {
+				pass.Report(analysis.Diagnostic{Pos: n.Pos(), Message: n.(*ast.FuncDecl).Name.Name})
+			})
+			return nil, nil
+		},
+	}
+	p := loadTestdata(t, "vet")
+	diagnostics, err := RunAnalyzers(p, funcs, assign.Analyzer)
+	require.NoError(t, err)
+	var messages []string
+	for _, d := range diagnostics {
+		messages = append(messages, d.Category+": "+d.Message)
+	}
+	assert.Equal(t, []string{"funcs: F", "assign: self-assignment of n to n"}, messages)
+	_, err = RunAnalyzers(p, &analysis.Analyzer{
+		Name: "fail",
+		Doc:  "fail",
+		Run:  func(*analysis.Pass) (interface{}, error) { return nil, errors.New("failed") },
+	})
+	assert.EqualError(t, err, "cannot run analyzer fail: failed")
+}
@@ -0,0 +1,118 @@
+package forklift
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// ASMError is a mismatch between an assembly function and its Go declaration.
+type ASMError struct {
+	// Function is the function name.
+	Function string
+
+	// Expected is what the Go declaration expects, like "b+4(FP)" or "int64 is 8-byte value",
+	// or empty if unknown.
+	Expected string
+
+	// Got is what the assembly function has, like "invalid offset b+8(FP)".
+	Got string
+}
+
+// asmError returns the ASMError for the asmdecl message,
+// like "[amd64] F: invalid offset b+8(FP); expected b+4(FP)".
+func asmError(message string) ASMError {
+	if strings.HasPrefix(message, "[") {
+		if i := strings.Index(message, "] "); i >= 0 {
+			message = message[i+2:]
+		}
+	}
+	var e ASMError
+	if name, rest, ok := strings.Cut(message, ": "); ok && !strings.Contains(name, " ") {
+		e.Function = name
+		message = rest
+	}
+	e.Got, e.Expected, _ = strings.Cut(message, "; ")
+	e.Expected = strings.TrimPrefix(e.Expected, "expected ")
+	return e
+}
+
+// LoadPackageWithASMCheck returns the package for path,
+// and the mismatches between its assembly functions and their Go declarations,
+// found by "go vet -asmdecl" for the GOARCH in Env, or in the current environment if Env is nil.
+// Dir, Env, and Flags are used for the go command.
+// It returns [ErrNotFound] if the package is not found, and other errors.
+func (l Loader) LoadPackageWithASMCheck(path string) (*packages.Package, []ASMError, error) {
+	p, err := l.LoadPackage(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	args := append(append([]string{"vet", "-asmdecl", "-json"}, l.Flags...), path)
+	var cmd *exec.Cmd
+	if l.Context != nil {
+		cmd = exec.CommandContext(l.Context, "go", args...)
+	} else {
+		cmd = exec.Command("go", args...)
+	}
+	cmd.Dir = l.Dir
+	cmd.Env = l.Env
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, nil, fmt.Errorf("cannot run go vet: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	// The JSON output is written to stdout by newer go commands,
+	// and to stderr after a "# path" line for each package by older ones.
+	out := stdout.String()
+	if strings.TrimSpace(out) == "" {
+		out = stderr.String()
+	}
+	var output bytes.Buffer
+	for _, line := range strings.SplitAfter(out, "\n") {
+		if !strings.HasPrefix(line, "#") {
+			output.WriteString(line)
+		}
+	}
+	var errs []ASMError
+	for d := json.NewDecoder(&output); d.More(); {
+		var results map[string]map[string]json.RawMessage
+		if err := d.Decode(&results); err != nil {
+			return nil, nil, fmt.Errorf("cannot decode go vet output: %v", err)
+		}
+		for _, analyzers := range results {
+			raw, ok := analyzers["asmdecl"]
+			if !ok {
+				continue
+			}
+			var diags []struct {
+				Message string `json:"message"`
+			}
+			if err := json.Unmarshal(raw, &diags); err != nil {
+				var failure struct {
+					Err string `json:"error"`
+				}
+				if json.Unmarshal(raw, &failure) == nil && failure.Err != "" {
+					return nil, nil, fmt.Errorf("cannot run analyzer asmdecl: %s", failure.Err)
+				}
+				return nil, nil, fmt.Errorf("cannot decode go vet output: %v", err)
+			}
+			for _, diag := range diags {
+				errs = append(errs, asmError(diag.Message))
+			}
+		}
+	}
+	return p, errs, nil
+}
+
+// LoadPackageWithASMCheck returns the package for path,
+// and the mismatches between its assembly functions and their Go declarations,
+// found by "go vet -asmdecl" for the current GOARCH.
+// It returns [ErrNotFound] if the package is not found, and other errors.
+func LoadPackageWithASMCheck(path string) (*packages.Package, []ASMError, error) {
+	return Loader{Mode: DefaultMode}.LoadPackageWithASMCheck(path)
+}
@@ -0,0 +1,32 @@
+package forklift
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestLoadPackageWithASMCheck(t *testing.T) {
+	t.Parallel()
+	l := Loader{Mode: packages.NeedName, Env: append(os.Environ(), "GOARCH=amd64")}
+	p, errs, err := l.LoadPackageWithASMCheck("./testdata/asm")
+	require.NoError(t, err)
+	assert.Equal(t, "asm", p.Name)
+	assert.Equal(t, []ASMError{
+		{Function: "Sub", Expected: "$...-12", Got: "wrong argument size 24"},
+		{Function: "Sub", Expected: "b+4(FP)", Got: "invalid offset b+8(FP)"},
+		{Function: "Sub", Expected: "ret+8(FP)", Got: "invalid offset ret+16(FP)"},
+		{Function: "Neg", Expected: "int64 is 8-byte value", Got: "invalid MOVL of a+0(FP)"},
+	}, errs)
+	_, _, err = l.LoadPackageWithASMCheck("./testdata/nonexistent")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestASMError(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, ASMError{Function: "F", Got: "function F missing Go declaration"}, asmError("[amd64] F: function F missing Go declaration"))
+	assert.Equal(t, ASMError{Got: "cannot check cross-package assembly function: F is in package p"}, asmError("[amd64] cannot check cross-package assembly function: F is in package p"))
+}
@@ -0,0 +1,66 @@
+package forklift
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// TypeAssertInfo is a type assertion without the comma-ok form.
+type TypeAssertInfo struct {
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+
+	// AssertedType is the asserted type expression.
+	AssertedType string
+}
+
+// commaOK returns the type assertions in f that use the comma-ok form.
+func commaOK(f *ast.File) map[*ast.TypeAssertExpr]bool {
+	ok := map[*ast.TypeAssertExpr]bool{}
+	ast.Inspect(f, func(n ast.Node) bool {
+		var rhs ast.Expr
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Lhs) == 2 && len(n.Rhs) == 1 {
+				rhs = n.Rhs[0]
+			}
+		case *ast.ValueSpec:
+			if len(n.Names) == 2 && len(n.Values) == 1 {
+				rhs = n.Values[0]
+			}
+		}
+		if a, isAssert := ast.Unparen(rhs).(*ast.TypeAssertExpr); isAssert {
+			ok[a] = true
+		}
+		return true
+	})
+	return ok
+}
+
+// FindUnsafeTypeAssertions returns the type assertions in p that do not use the comma-ok form
+// and so panic on failure. Type switches are excluded.
+// It requires syntax.
+func FindUnsafeTypeAssertions(p *packages.Package) ([]TypeAssertInfo, error) {
+	if err := need(p, packages.NeedSyntax); err != nil {
+		return nil, err
+	}
+	var asserts []TypeAssertInfo
+	for _, f := range p.Syntax {
+		ok := commaOK(f)
+		ast.Inspect(f, func(n ast.Node) bool {
+			a, isAssert := n.(*ast.TypeAssertExpr)
+			if !isAssert || a.Type == nil || ok[a] {
+				return true
+			}
+			file, line := position(p, a.Pos())
+			asserts = append(asserts, TypeAssertInfo{File: file, Line: line, AssertedType: types.ExprString(a.Type)})
+			return true
+		})
+	}
+	return asserts, nil
+}
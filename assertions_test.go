@@ -0,0 +1,21 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindUnsafeTypeAssertions(t *testing.T) {
+	t.Parallel()
+	asserts, err := FindUnsafeTypeAssertions(loadTestdata(t, "assertions"))
+	require.NoError(t, err)
+	for i := range asserts {
+		asserts[i].File = ""
+	}
+	assert.Equal(t, []TypeAssertInfo{
+		{Line: 6, AssertedType: "int"},
+		{Line: 13, AssertedType: "error"},
+	}, asserts)
+}
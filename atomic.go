@@ -0,0 +1,45 @@
+package forklift
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// AtomicOp is a call to a sync/atomic function or method.
+type AtomicOp struct {
+	// FuncName is the function name, like "AddInt64" or "Int64.Add".
+	FuncName string
+
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+}
+
+// FindAtomicOperations returns the calls to sync/atomic functions and methods in p.
+// It requires syntax and types info.
+func FindAtomicOperations(p *packages.Package) ([]AtomicOp, error) {
+	if err := need(p, packages.NeedSyntax|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	var ops []AtomicOp
+	for _, f := range p.Syntax {
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			fn := typeutil.StaticCallee(p.TypesInfo, call)
+			if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != "sync/atomic" {
+				return true
+			}
+			file, line := position(p, call.Pos())
+			ops = append(ops, AtomicOp{FuncName: funcName(fn), File: file, Line: line})
+			return true
+		})
+	}
+	return ops, nil
+}
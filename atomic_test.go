@@ -0,0 +1,20 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindAtomicOperations(t *testing.T) {
+	t.Parallel()
+	ops, err := FindAtomicOperations(loadTestdata(t, "atomic"))
+	require.NoError(t, err)
+	var names []string
+	for _, op := range ops {
+		names = append(names, op.FuncName)
+	}
+	assert.Equal(t, []string{"AddInt64", "Int64.Add", "Int64.Load"}, names)
+	assert.Equal(t, 11, ops[0].Line)
+}
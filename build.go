@@ -0,0 +1,172 @@
+package forklift
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// withFlags returns l with flags added after Flags.
+func (l Loader) withFlags(flags ...string) Loader {
+	l.Flags = append(l.Flags[:len(l.Flags):len(l.Flags)], flags...)
+	return l
+}
+
+// LoadPackageWithGCFlags returns the package for path, built with the gc compiler flags gcflags,
+// like "-m" or "all=-N -l".
+// The flag is added after Flags, so if Flags has a -gcflags flag for the same packages,
+// gcflags replaces it instead of being combined with it.
+// It returns [ErrNotFound] if the package is not found, and other errors.
+func (l Loader) LoadPackageWithGCFlags(path string, gcflags string) (*packages.Package, error) {
+	return l.withFlags("-gcflags=" + gcflags).LoadPackage(path)
+}
+
+// LoadPackageWithGCFlags returns the package for path, built with the gc compiler flags gcflags,
+// like "-m" or "all=-N -l".
+// It returns [ErrNotFound] if the package is not found, and other errors.
+func LoadPackageWithGCFlags(path string, gcflags string) (*packages.Package, error) {
+	return Loader{Mode: DefaultMode}.LoadPackageWithGCFlags(path, gcflags)
+}
+
+// LoadPackageWithExperiment returns the package for path, built with the GOEXPERIMENT feature flags experiments,
+// like "loopvar" or "rangefunc".
+// The GOEXPERIMENT variable is added after Env, or after the current environment if Env is nil.
+// Type information depends on the experiments supported by the go/types package this is built with.
+// It returns [ErrNotFound] if the package is not found, and other errors.
+func (l Loader) LoadPackageWithExperiment(path string, experiments ...string) (*packages.Package, error) {
+	env := l.Env
+	if env == nil {
+		env = os.Environ()
+	}
+	l.Env = append(env[:len(env):len(env)], "GOEXPERIMENT="+strings.Join(experiments, ","))
+	return l.LoadPackage(path)
+}
+
+// LoadPackageWithExperiment returns the package for path, built with the GOEXPERIMENT feature flags experiments,
+// like "loopvar" or "rangefunc".
+// It returns [ErrNotFound] if the package is not found, and other errors.
+func LoadPackageWithExperiment(path string, experiments ...string) (*packages.Package, error) {
+	return Loader{Mode: DefaultMode}.LoadPackageWithExperiment(path, experiments...)
+}
+
+// LoadPackageWithRace returns the package for path, built with the race detector.
+// The race detector requires cgo.
+// It returns [ErrNotFound] if the package is not found, and other errors.
+func (l Loader) LoadPackageWithRace(path string) (*packages.Package, error) {
+	return l.withFlags("-race").LoadPackage(path)
+}
+
+// LoadPackageWithRace returns the package for path, built with the race detector.
+// The race detector requires cgo.
+// It returns [ErrNotFound] if the package is not found, and other errors.
+func LoadPackageWithRace(path string) (*packages.Package, error) {
+	return Loader{Mode: DefaultMode}.LoadPackageWithRace(path)
+}
+
+// LoadPackageWithGoroot returns the package for path, loaded with the Go installation at goroot.
+// The GOROOT variable is added after Env, or after the current environment if Env is nil,
+// and the PATH variable is added with goroot/bin first, for the commands run by the go command.
+// The go command itself is found in the PATH of the current process, not in Env,
+// so goroot must be for the same Go version as that go command.
+// It returns an error if the version in goroot/VERSION is not the version of the go command.
+// It returns [ErrNotFound] if the package is not found, and other errors.
+func (l Loader) LoadPackageWithGoroot(path, goroot string) (*packages.Package, error) {
+	b, err := os.ReadFile(filepath.Join(goroot, "VERSION"))
+	if err != nil {
+		return nil, loadError(fmt.Errorf("cannot read Go version of %s: %v", goroot, err))
+	}
+	version, _, _ := strings.Cut(string(b), "\n")
+	version = strings.TrimSpace(version)
+	var cmd *exec.Cmd
+	if l.Context != nil {
+		cmd = exec.CommandContext(l.Context, "go", "env", "GOVERSION")
+	} else {
+		cmd = exec.Command("go", "env", "GOVERSION")
+	}
+	cmd.Dir = l.Dir
+	cmd.Env = l.Env
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, loadError(fmt.Errorf("cannot get go command version: %v", err))
+	}
+	if goVersion := strings.TrimSpace(string(out)); version != goVersion {
+		return nil, loadError(fmt.Errorf("%s has version %s, but the go command has version %s", goroot, version, goVersion))
+	}
+	env := l.Env
+	if env == nil {
+		env = os.Environ()
+	}
+	bin := filepath.Join(goroot, "bin")
+	pathVar := bin
+	for _, v := range env {
+		if dirs, ok := strings.CutPrefix(v, "PATH="); ok && dirs != "" {
+			pathVar = bin + string(filepath.ListSeparator) + dirs
+		}
+	}
+	l.Env = append(env[:len(env):len(env)], "GOROOT="+goroot, "PATH="+pathVar)
+	return l.LoadPackage(path)
+}
+
+// LoadPackageWithGoroot returns the package for path, loaded with the Go installation at goroot.
+// It returns an error if goroot is not for the same Go version as the go command in PATH.
+// It returns [ErrNotFound] if the package is not found, and other errors.
+func LoadPackageWithGoroot(path, goroot string) (*packages.Package, error) {
+	return Loader{Mode: DefaultMode}.LoadPackageWithGoroot(path, goroot)
+}
+
+// LoadPackageWithModFile returns the package for path, built with the module file modFile instead of go.mod.
+// The go.sum file used is modFile with a ".sum" extension instead of ".mod".
+// The flag is added before Flags.
+// The -modfile flag requires Go 1.14 or later.
+// It returns [ErrNotFound] if the package is not found, and other errors.
+func (l Loader) LoadPackageWithModFile(path, modFile string) (*packages.Package, error) {
+	l.Flags = append([]string{"-modfile=" + modFile}, l.Flags...)
+	p, err := l.LoadPackage(path)
+	if err != nil && strings.Contains(err.Error(), "flag provided but not defined: -modfile") {
+		return nil, loadError(errors.New("-modfile requires Go 1.14 or later"))
+	}
+	return p, err
+}
+
+// LoadPackageWithModFile returns the package for path, built with the module file modFile instead of go.mod.
+// The -modfile flag requires Go 1.14 or later.
+// It returns [ErrNotFound] if the package is not found, and other errors.
+func LoadPackageWithModFile(path, modFile string) (*packages.Package, error) {
+	return Loader{Mode: DefaultMode}.LoadPackageWithModFile(path, modFile)
+}
+
+// LoadPackageWithTrimpath returns the package for path, built with file system paths removed from the compiled files.
+// Source file names, like those in GoFiles and in the positions of Fset, are not affected,
+// since they are needed to read the files.
+// It returns [ErrNotFound] if the package is not found, and other errors.
+func (l Loader) LoadPackageWithTrimpath(path string) (*packages.Package, error) {
+	return l.withFlags("-trimpath").LoadPackage(path)
+}
+
+// LoadPackageWithTrimpath returns the package for path, built with file system paths removed from the compiled files.
+// It returns [ErrNotFound] if the package is not found, and other errors.
+func LoadPackageWithTrimpath(path string) (*packages.Package, error) {
+	return Loader{Mode: DefaultMode}.LoadPackageWithTrimpath(path)
+}
+
+// LoadPackageWithCoverpkg returns the package for path, built with atomic coverage instrumentation
+// for the packages matching the comma-separated patterns coverpkg.
+// CompiledGoFiles, Syntax, and the type information are of the instrumented files.
+// The instrumented files import sync/atomic, but the go command does not report it as a dependency,
+// so packages that do not import sync/atomic directly cannot be type checked.
+// It returns [ErrNotFound] if the package is not found, and other errors.
+func (l Loader) LoadPackageWithCoverpkg(path, coverpkg string) (*packages.Package, error) {
+	return l.withFlags("-covermode=atomic", "-coverpkg="+coverpkg).LoadPackage(path)
+}
+
+// LoadPackageWithCoverpkg returns the package for path, built with atomic coverage instrumentation
+// for the packages matching the comma-separated patterns coverpkg.
+// It returns [ErrNotFound] if the package is not found, and other errors.
+func LoadPackageWithCoverpkg(path, coverpkg string) (*packages.Package, error) {
+	return Loader{Mode: DefaultMode}.LoadPackageWithCoverpkg(path, coverpkg)
+}
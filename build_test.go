@@ -0,0 +1,94 @@
+package forklift
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestLoadPackageWithGCFlags(t *testing.T) {
+	t.Parallel()
+	l := Loader{Mode: packages.NeedName, Flags: []string{"-gcflags=-m"}}
+	p, err := l.LoadPackageWithGCFlags("./testdata/typegraph", "all=-N -l")
+	require.NoError(t, err)
+	assert.Equal(t, "typegraph", p.Name)
+	assert.Equal(t, []string{"-gcflags=-m"}, l.Flags)
+	_, err = l.LoadPackageWithGCFlags("./testdata/typegraph", "=-N")
+	assert.Error(t, err)
+}
+
+func TestLoadPackageWithExperiment(t *testing.T) {
+	t.Parallel()
+	p, err := LoadPackageWithExperiment("./testdata/typegraph", "loopvar")
+	require.NoError(t, err)
+	assert.Equal(t, "typegraph", p.Name)
+	_, err = LoadPackageWithExperiment("./testdata/typegraph", "bogus")
+	assert.ErrorContains(t, err, "bogus")
+}
+
+func TestLoadPackageWithRace(t *testing.T) {
+	t.Parallel()
+	p, err := Loader{Mode: packages.NeedName | packages.NeedFiles, Env: append(os.Environ(), "CGO_ENABLED=1")}.LoadPackageWithRace("./testdata/races")
+	require.NoError(t, err)
+	assert.Equal(t, "races", p.Name)
+	_, err = Loader{Mode: packages.NeedName, Env: append(os.Environ(), "CGO_ENABLED=0")}.LoadPackageWithRace("./testdata/races")
+	assert.Error(t, err)
+}
+
+func TestLoadPackageWithGoroot(t *testing.T) {
+	t.Parallel()
+	l := Loader{Mode: packages.NeedName | packages.NeedFiles}
+	p, err := l.LoadPackageWithGoroot("strings", runtime.GOROOT())
+	require.NoError(t, err)
+	require.NotEmpty(t, p.GoFiles)
+	assert.True(t, strings.HasPrefix(p.GoFiles[0], filepath.Join(runtime.GOROOT(), "src")))
+	_, err = l.LoadPackageWithGoroot("strings", filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+	goroot := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(goroot, "VERSION"), []byte("go1.0\ntime 2012-03-28T00:00:00Z\n"), 0o600))
+	_, err = l.LoadPackageWithGoroot("strings", goroot)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has version go1.0, but the go command has version go1.")
+}
+
+func TestLoadPackageWithModFile(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	for _, ext := range []string{".mod", ".sum"} {
+		b, err := os.ReadFile("go" + ext)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "alt"+ext), b, 0o600))
+	}
+	l := Loader{Mode: packages.NeedName | packages.NeedModule}
+	p, err := l.LoadPackageWithModFile("./testdata/typegraph", filepath.Join(dir, "alt.mod"))
+	require.NoError(t, err)
+	require.NotNil(t, p.Module)
+	assert.Equal(t, filepath.Join(dir, "alt.mod"), p.Module.GoMod)
+	_, err = l.LoadPackageWithModFile("./testdata/typegraph", filepath.Join(dir, "missing.mod"))
+	assert.Error(t, err)
+}
+
+func TestLoadPackageWithTrimpath(t *testing.T) {
+	t.Parallel()
+	p, err := LoadPackageWithTrimpath("./testdata/typegraph")
+	require.NoError(t, err)
+	require.Len(t, p.GoFiles, 1)
+	assert.True(t, filepath.IsAbs(p.GoFiles[0]))
+	assert.Equal(t, p.GoFiles[0], p.Fset.Position(p.Syntax[0].Pos()).Filename)
+}
+
+func TestLoadPackageWithCoverpkg(t *testing.T) {
+	t.Parallel()
+	p, err := Loader{Mode: packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles}.LoadPackageWithCoverpkg("./testdata/calls/caller", "./testdata/calls/...")
+	require.NoError(t, err)
+	assert.NotEqual(t, p.GoFiles, p.CompiledGoFiles)
+	p, err = LoadPackageWithCoverpkg("./testdata/atomic", "./testdata/atomic")
+	require.NoError(t, err)
+	assert.NotNil(t, p.Types.Scope().Lookup("n"))
+}
@@ -0,0 +1,52 @@
+package forklift
+
+import (
+	"fmt"
+
+	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/callgraph/cha"
+	"golang.org/x/tools/go/callgraph/rta"
+	"golang.org/x/tools/go/callgraph/vta"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+// CallGraphAlgo is a call graph construction algorithm.
+type CallGraphAlgo int
+
+const (
+	// AlgoCHA is Class Hierarchy Analysis.
+	AlgoCHA CallGraphAlgo = iota
+
+	// AlgoRTA is Rapid Type Analysis, with the functions and methods declared in the package as roots.
+	AlgoRTA
+
+	// AlgoVTA is Variable Type Analysis, refined from Class Hierarchy Analysis.
+	AlgoVTA
+)
+
+// BuildCallGraph returns the call graph of p built with algo.
+// Calls into dependencies end at the callee, since their function bodies are not built.
+// It requires syntax, types, and types info.
+func BuildCallGraph(p *packages.Package, algo CallGraphAlgo) (*callgraph.Graph, error) {
+	s, err := GenerateSSA(p, ssa.InstantiateGenerics)
+	if err != nil {
+		return nil, err
+	}
+	switch algo {
+	case AlgoCHA:
+		return cha.CallGraph(s.Prog), nil
+	case AlgoRTA:
+		var roots []*ssa.Function
+		for f := range ssautil.AllFunctions(s.Prog) {
+			if f.Pkg == s && f.Synthetic == "" {
+				roots = append(roots, f)
+			}
+		}
+		return rta.Analyze(roots, true).CallGraph, nil
+	case AlgoVTA:
+		return vta.CallGraph(ssautil.AllFunctions(s.Prog), cha.CallGraph(s.Prog)), nil
+	}
+	return nil, fmt.Errorf("invalid call graph algorithm: %d", algo)
+}
@@ -0,0 +1,33 @@
+package forklift
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCallGraph(t *testing.T) {
+	t.Parallel()
+	p := loadTestdata(t, "calls/caller")
+	for _, algo := range []CallGraphAlgo{AlgoCHA, AlgoRTA, AlgoVTA} {
+		algo := algo
+		t.Run(fmt.Sprint(algo), func(t *testing.T) {
+			t.Parallel()
+			g, err := BuildCallGraph(p, algo)
+			require.NoError(t, err)
+			var callees []string
+			for f, n := range g.Nodes {
+				if f != nil && f.Pkg != nil && f.Pkg.Pkg == p.Types && f.Name() == "A" {
+					for _, e := range n.Out {
+						callees = append(callees, e.Callee.Func.String())
+					}
+				}
+			}
+			assert.Contains(t, callees, "github.com/willfaught/forklift/testdata/calls/callee.F")
+		})
+	}
+	_, err := BuildCallGraph(p, -1)
+	assert.EqualError(t, err, "invalid call graph algorithm: -1")
+}
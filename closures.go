@@ -0,0 +1,63 @@
+package forklift
+
+import (
+	"go/ast"
+	"go/types"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// ClosureInfo is a function literal that captures variables.
+type ClosureInfo struct {
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+
+	// CapturedVars is the sorted names of the captured variables.
+	CapturedVars []string
+}
+
+// FindComplexClosures returns the function literals in p that capture more than maxCaptures local variables
+// declared outside of them.
+// Package-level variables of p and other packages are not captured.
+// It requires syntax and types info.
+func FindComplexClosures(p *packages.Package, maxCaptures int) ([]ClosureInfo, error) {
+	if err := need(p, packages.NeedSyntax|packages.NeedTypes|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	var closures []ClosureInfo
+	for _, f := range p.Syntax {
+		ast.Inspect(f, func(n ast.Node) bool {
+			lit, ok := n.(*ast.FuncLit)
+			if !ok {
+				return true
+			}
+			captured := map[*types.Var]bool{}
+			ast.Inspect(lit.Body, func(n ast.Node) bool {
+				id, ok := n.(*ast.Ident)
+				if !ok {
+					return true
+				}
+				v, ok := p.TypesInfo.Uses[id].(*types.Var)
+				if ok && !v.IsField() && v.Pkg() == p.Types && v.Parent() != v.Pkg().Scope() && (v.Pos() < lit.Pos() || v.Pos() >= lit.End()) {
+					captured[v] = true
+				}
+				return true
+			})
+			if len(captured) > maxCaptures {
+				var names []string
+				for v := range captured {
+					names = append(names, v.Name())
+				}
+				sort.Strings(names)
+				file, line := position(p, lit.Pos())
+				closures = append(closures, ClosureInfo{File: file, Line: line, CapturedVars: names})
+			}
+			return true
+		})
+	}
+	return closures, nil
+}
@@ -0,0 +1,35 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindComplexClosures(t *testing.T) {
+	t.Parallel()
+	p := loadTestdata(t, "closures")
+	for _, test := range []struct {
+		max  int
+		want []ClosureInfo
+	}{
+		{max: 0, want: []ClosureInfo{
+			{Line: 9, CapturedVars: []string{"a"}},
+			{Line: 11, CapturedVars: []string{"a", "b", "c"}},
+			{Line: 13, CapturedVars: []string{"a", "d"}},
+			{Line: 20, CapturedVars: []string{"a"}},
+		}},
+		{max: 2, want: []ClosureInfo{
+			{Line: 11, CapturedVars: []string{"a", "b", "c"}},
+		}},
+		{max: 3},
+	} {
+		closures, err := FindComplexClosures(p, test.max)
+		require.NoError(t, err)
+		for i := range closures {
+			closures[i].File = ""
+		}
+		assert.Equal(t, test.want, closures)
+	}
+}
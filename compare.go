@@ -0,0 +1,199 @@
+package forklift
+
+import (
+	"fmt"
+	"go/types"
+	"path/filepath"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// PackageDiff is the difference between two versions of a package.
+type PackageDiff struct {
+	// AddedFiles is the sorted base names of the added files.
+	AddedFiles []string
+
+	// RemovedFiles is the sorted base names of the removed files.
+	RemovedFiles []string
+
+	// AddedImports is the sorted added import paths.
+	AddedImports []string
+
+	// RemovedImports is the sorted removed import paths.
+	RemovedImports []string
+
+	// AddedExports is the sorted names of the added exported declarations.
+	AddedExports []string
+
+	// RemovedExports is the sorted names of the removed exported declarations.
+	RemovedExports []string
+
+	// ChangedExports is the sorted names of the exported declarations whose declarations changed.
+	ChangedExports []string
+}
+
+// diffKeys returns the sorted keys in after but not before, and in before but not after.
+func diffKeys[V any](before, after map[string]V) (added, removed []string) {
+	for k := range after {
+		if _, ok := before[k]; !ok {
+			added = append(added, k)
+		}
+	}
+	for k := range before {
+		if _, ok := after[k]; !ok {
+			removed = append(removed, k)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// exports returns the declarations of the exported package-level declarations in p
+// and the exported methods of its exported named types by name.
+// Methods are qualified by their receiver type name.
+func exports(p *packages.Package) map[string]string {
+	qualifier := func(other *types.Package) string {
+		if other.Path() == p.PkgPath {
+			return ""
+		}
+		return other.Path()
+	}
+	decls := map[string]string{}
+	scope := p.Types.Scope()
+	for _, name := range scope.Names() {
+		obj := scope.Lookup(name)
+		if !obj.Exported() {
+			continue
+		}
+		decls[name] = types.ObjectString(obj, qualifier)
+		if t, ok := obj.(*types.TypeName); ok && !t.IsAlias() {
+			if named, ok := t.Type().(*types.Named); ok {
+				for i := 0; i < named.NumMethods(); i++ {
+					if m := named.Method(i); m.Exported() {
+						decls[name+"."+m.Name()] = types.ObjectString(m, qualifier)
+					}
+				}
+			}
+		}
+	}
+	return decls
+}
+
+// ComparePackages returns the difference between before and after.
+// Files are compared by base name.
+// It requires files, imports, and types.
+func ComparePackages(before, after *packages.Package) (*PackageDiff, error) {
+	for _, p := range []*packages.Package{before, after} {
+		if err := need(p, packages.NeedTypes); err != nil {
+			return nil, err
+		}
+	}
+	var d PackageDiff
+	files := func(p *packages.Package) map[string]string {
+		m := map[string]string{}
+		for _, f := range p.GoFiles {
+			m[filepath.Base(f)] = f
+		}
+		return m
+	}
+	d.AddedFiles, d.RemovedFiles = diffKeys(files(before), files(after))
+	imports := func(p *packages.Package) map[string]string {
+		m := map[string]string{}
+		for path := range p.Imports {
+			m[path] = path
+		}
+		return m
+	}
+	d.AddedImports, d.RemovedImports = diffKeys(imports(before), imports(after))
+	beforeExports, afterExports := exports(before), exports(after)
+	d.AddedExports, d.RemovedExports = diffKeys(beforeExports, afterExports)
+	for name, decl := range afterExports {
+		if beforeDecl, ok := beforeExports[name]; ok && beforeDecl != decl {
+			d.ChangedExports = append(d.ChangedExports, name)
+		}
+	}
+	sort.Strings(d.ChangedExports)
+	return &d, nil
+}
+
+// ErrorDiff is the difference between the errors of two versions of a package.
+type ErrorDiff struct {
+	// Path is the package path.
+	Path string
+
+	// Added is the sorted added errors, formatted like "type: file.go:1:2: message".
+	Added []string
+
+	// Removed is the sorted removed errors, formatted like "type: file.go:1:2: message".
+	Removed []string
+}
+
+// errorKinds is the names of the package error kinds.
+var errorKinds = map[packages.ErrorKind]string{
+	packages.UnknownError: "unknown",
+	packages.ListError:    "list",
+	packages.ParseError:   "parse",
+	packages.TypeError:    "type",
+}
+
+// errorKey returns the kind, position, and message of err,
+// with the directory of the position file removed.
+func errorKey(err packages.Error) string {
+	pos := err.Pos
+	if pos == "" {
+		pos = "-"
+	}
+	return fmt.Sprintf("%s: %s: %s", errorKinds[err.Kind], filepath.Base(pos), err.Msg)
+}
+
+// DiffPackageErrors returns the differences between the errors of the packages in before and after
+// with the same paths, sorted by path.
+// Errors are compared by kind, position file name and line and column, and message,
+// so packages loaded in different directories can be compared.
+// Packages in only before or after have all their errors removed or added.
+// Packages without differences are excluded.
+func DiffPackageErrors(before, after []*packages.Package) ([]ErrorDiff, error) {
+	errs := func(ps []*packages.Package) (map[string]map[string]bool, error) {
+		m := map[string]map[string]bool{}
+		for _, p := range ps {
+			if p == nil {
+				return nil, ErrNotFound
+			}
+			if m[p.PkgPath] == nil {
+				m[p.PkgPath] = map[string]bool{}
+			}
+			for _, err := range p.Errors {
+				m[p.PkgPath][errorKey(err)] = true
+			}
+		}
+		return m, nil
+	}
+	beforeErrs, err := errs(before)
+	if err != nil {
+		return nil, err
+	}
+	afterErrs, err := errs(after)
+	if err != nil {
+		return nil, err
+	}
+	paths := map[string]bool{}
+	for path := range beforeErrs {
+		paths[path] = true
+	}
+	for path := range afterErrs {
+		paths[path] = true
+	}
+	var diffs []ErrorDiff
+	for path := range paths {
+		added, removed := diffKeys(beforeErrs[path], afterErrs[path])
+		if added != nil || removed != nil {
+			diffs = append(diffs, ErrorDiff{Path: path, Added: added, Removed: removed})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Path < diffs[j].Path
+	})
+	return diffs, nil
+}
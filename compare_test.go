@@ -0,0 +1,71 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestComparePackages(t *testing.T) {
+	t.Parallel()
+	d, err := ComparePackages(loadTestdata(t, "compare/before"), loadTestdata(t, "compare/after"))
+	require.NoError(t, err)
+	assert.Equal(t, &PackageDiff{
+		AddedFiles:     []string{"c.go"},
+		RemovedFiles:   []string{"b.go"},
+		AddedImports:   []string{"strings"},
+		RemovedImports: []string{"fmt"},
+		AddedExports:   []string{"Added"},
+		RemovedExports: []string{"Removed"},
+		ChangedExports: []string{"Changed", "T.M"},
+	}, d)
+}
+
+func TestDiffPackageErrors(t *testing.T) {
+	t.Parallel()
+	before := []*packages.Package{
+		{PkgPath: "a", Errors: []packages.Error{{Msg: "same"}, {Msg: "fixed"}}},
+		{PkgPath: "b", Errors: []packages.Error{{Msg: "gone"}}},
+		{PkgPath: "c"},
+	}
+	after := []*packages.Package{
+		{PkgPath: "a", Errors: []packages.Error{{Msg: "same"}, {Pos: "a.go:1:2", Msg: "new", Kind: packages.TypeError}}},
+		{PkgPath: "c"},
+		{PkgPath: "d", Errors: []packages.Error{{Msg: "new", Kind: packages.ListError}}},
+	}
+	diffs, err := DiffPackageErrors(before, after)
+	require.NoError(t, err)
+	assert.Equal(t, []ErrorDiff{
+		{Path: "a", Added: []string{"type: a.go:1:2: new"}, Removed: []string{"unknown: -: fixed"}},
+		{Path: "b", Removed: []string{"unknown: -: gone"}},
+		{Path: "d", Added: []string{"list: -: new"}},
+	}, diffs)
+	diffs, err = DiffPackageErrors(before, before)
+	require.NoError(t, err)
+	assert.Empty(t, diffs)
+	_, err = DiffPackageErrors(before, []*packages.Package{nil})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestDiffPackageErrorsDirectories(t *testing.T) {
+	t.Parallel()
+	local := []*packages.Package{{PkgPath: "a", Errors: []packages.Error{
+		{Pos: "/home/me/src/a/a.go:3:4", Msg: "undefined: x", Kind: packages.TypeError},
+	}}}
+	ci := []*packages.Package{{PkgPath: "a", Errors: []packages.Error{
+		{Pos: "/builds/job/a/a.go:3:4", Msg: "undefined: x", Kind: packages.TypeError},
+	}}}
+	diffs, err := DiffPackageErrors(local, ci)
+	require.NoError(t, err)
+	assert.Empty(t, diffs)
+	ci[0].Errors[0].Kind = packages.ParseError
+	diffs, err = DiffPackageErrors(local, ci)
+	require.NoError(t, err)
+	assert.Equal(t, []ErrorDiff{{
+		Path:    "a",
+		Added:   []string{"parse: a.go:3:4: undefined: x"},
+		Removed: []string{"type: a.go:3:4: undefined: x"},
+	}}, diffs)
+}
@@ -0,0 +1,234 @@
+package forklift
+
+import (
+	"go/ast"
+	"go/token"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// FuncComplexity is the cyclomatic complexity of a function.
+type FuncComplexity struct {
+	// Name is the function name. Methods are qualified by their receiver type name.
+	Name string
+
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+
+	// Complexity is the cyclomatic complexity.
+	Complexity int
+}
+
+// EstimateCyclomaticComplexity returns the cyclomatic complexity of the function declarations in p.
+// The complexity is one plus the number of if, for, and range statements,
+// non-default case clauses, and && and || operators.
+// It requires syntax.
+func EstimateCyclomaticComplexity(p *packages.Package) ([]FuncComplexity, error) {
+	if err := need(p, packages.NeedSyntax); err != nil {
+		return nil, err
+	}
+	var fs []FuncComplexity
+	for _, f := range p.Syntax {
+		for _, d := range f.Decls {
+			d, ok := d.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			complexity := 1
+			ast.Inspect(d, func(n ast.Node) bool {
+				switch n := n.(type) {
+				case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt:
+					complexity++
+				case *ast.CaseClause:
+					if n.List != nil {
+						complexity++
+					}
+				case *ast.CommClause:
+					if n.Comm != nil {
+						complexity++
+					}
+				case *ast.BinaryExpr:
+					if n.Op == token.LAND || n.Op == token.LOR {
+						complexity++
+					}
+				}
+				return true
+			})
+			file, line := position(p, d.Pos())
+			fs = append(fs, FuncComplexity{Name: declName(d), File: file, Line: line, Complexity: complexity})
+		}
+	}
+	return fs, nil
+}
+
+// ComplexFunctions returns the functions in fs with a complexity greater than threshold.
+func ComplexFunctions(fs []FuncComplexity, threshold int) []FuncComplexity {
+	var over []FuncComplexity
+	for _, f := range fs {
+		if f.Complexity > threshold {
+			over = append(over, f)
+		}
+	}
+	return over
+}
+
+// FuncLengthInfo is the length of a function.
+type FuncLengthInfo struct {
+	// Name is the function name. Methods are qualified by their receiver type name.
+	Name string
+
+	// File is the file name.
+	File string
+
+	// StartLine is the line number of the func keyword.
+	StartLine int
+
+	// EndLine is the line number of the closing brace.
+	EndLine int
+
+	// NumLines is the number of lines, including comments and blank lines.
+	NumLines int
+}
+
+// FindLongFunctions returns the function declarations in p with more than maxLines lines,
+// including comments and blank lines in the body.
+// It requires syntax.
+func FindLongFunctions(p *packages.Package, maxLines int) ([]FuncLengthInfo, error) {
+	if err := need(p, packages.NeedSyntax); err != nil {
+		return nil, err
+	}
+	var long []FuncLengthInfo
+	for _, f := range p.Syntax {
+		for _, d := range f.Decls {
+			d, ok := d.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			start, end := p.Fset.Position(d.Pos()), p.Fset.Position(d.End())
+			if n := end.Line - start.Line + 1; n > maxLines {
+				long = append(long, FuncLengthInfo{Name: declName(d), File: start.Filename, StartLine: start.Line, EndLine: end.Line, NumLines: n})
+			}
+		}
+	}
+	return long, nil
+}
+
+// NestingInfo is the deepest point of a deeply nested region.
+type NestingInfo struct {
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+
+	// Depth is the nesting depth.
+	Depth int
+
+	// EnclosingFunc is the enclosing function declaration name, or empty if none.
+	EnclosingFunc string
+}
+
+// FindDeepNesting returns the deepest point of each region in p nested more than maxDepth levels.
+// If, for, range, switch, and select statements and function literals are nesting levels,
+// except else-if statements, which are at the level of their if statements.
+// It requires syntax.
+func FindDeepNesting(p *packages.Package, maxDepth int) ([]NestingInfo, error) {
+	if err := need(p, packages.NeedSyntax); err != nil {
+		return nil, err
+	}
+	var deep []NestingInfo
+	for _, f := range p.Syntax {
+		for _, d := range f.Decls {
+			var fn string
+			if d, ok := d.(*ast.FuncDecl); ok {
+				fn = declName(d)
+			}
+			var region ast.Node
+			var nodes []ast.Node
+			var depths []int
+			ast.Inspect(d, func(n ast.Node) bool {
+				if n == nil {
+					nodes, depths = nodes[:len(nodes)-1], depths[:len(depths)-1]
+					return true
+				}
+				var parent ast.Node
+				var depth int
+				if len(nodes) > 0 {
+					parent, depth = nodes[len(nodes)-1], depths[len(depths)-1]
+				}
+				switch n.(type) {
+				case *ast.IfStmt:
+					if parent, ok := parent.(*ast.IfStmt); !ok || parent.Else != n {
+						depth++
+					}
+				case *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt, *ast.FuncLit:
+					depth++
+				}
+				nodes, depths = append(nodes, n), append(depths, depth)
+				if depth <= maxDepth {
+					return true
+				}
+				if region == nil || n.Pos() >= region.End() {
+					region = n
+					file, line := position(p, n.Pos())
+					deep = append(deep, NestingInfo{File: file, Line: line, Depth: depth, EnclosingFunc: fn})
+				} else if last := &deep[len(deep)-1]; depth > last.Depth {
+					last.File, last.Line = position(p, n.Pos())
+					last.Depth = depth
+				}
+				return true
+			})
+		}
+	}
+	return deep, nil
+}
+
+// SwitchInfo is a switch or select statement.
+type SwitchInfo struct {
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+
+	// NumCases is the number of case clauses, including default.
+	NumCases int
+
+	// Kind is "switch" or "select".
+	Kind string
+}
+
+// FindComplexSwitchStatements returns the switch, type switch, and select statements in p
+// with at least minCases case clauses, including default.
+// It requires syntax.
+func FindComplexSwitchStatements(p *packages.Package, minCases int) ([]SwitchInfo, error) {
+	if err := need(p, packages.NeedSyntax); err != nil {
+		return nil, err
+	}
+	var switches []SwitchInfo
+	for _, f := range p.Syntax {
+		ast.Inspect(f, func(n ast.Node) bool {
+			var body *ast.BlockStmt
+			kind := "switch"
+			switch n := n.(type) {
+			case *ast.SwitchStmt:
+				body = n.Body
+			case *ast.TypeSwitchStmt:
+				body = n.Body
+			case *ast.SelectStmt:
+				body, kind = n.Body, "select"
+			default:
+				return true
+			}
+			if len(body.List) >= minCases {
+				file, line := position(p, n.Pos())
+				switches = append(switches, SwitchInfo{File: file, Line: line, NumCases: len(body.List), Kind: kind})
+			}
+			return true
+		})
+	}
+	return switches, nil
+}
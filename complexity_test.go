@@ -0,0 +1,67 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateCyclomaticComplexity(t *testing.T) {
+	t.Parallel()
+	fs, err := EstimateCyclomaticComplexity(loadTestdata(t, "complexity"))
+	require.NoError(t, err)
+	for i := range fs {
+		fs[i].File = ""
+	}
+	assert.Equal(t, []FuncComplexity{
+		{Name: "simple", Line: 3, Complexity: 1},
+		{Name: "complex", Line: 5, Complexity: 9},
+	}, fs)
+	assert.Equal(t, fs[1:], ComplexFunctions(fs, 1))
+	assert.Empty(t, ComplexFunctions(fs, 9))
+}
+
+func TestFindLongFunctions(t *testing.T) {
+	t.Parallel()
+	long, err := FindLongFunctions(loadTestdata(t, "length"), 2)
+	require.NoError(t, err)
+	for i := range long {
+		long[i].File = ""
+	}
+	assert.Equal(t, []FuncLengthInfo{
+		{Name: "long", StartLine: 6, EndLine: 10, NumLines: 5},
+		{Name: "T.method", StartLine: 14, EndLine: 16, NumLines: 3},
+	}, long)
+}
+
+func TestFindDeepNesting(t *testing.T) {
+	t.Parallel()
+	p := loadTestdata(t, "nesting")
+	deep, err := FindDeepNesting(p, 2)
+	require.NoError(t, err)
+	for i := range deep {
+		deep[i].File = ""
+	}
+	assert.Equal(t, []NestingInfo{
+		{Line: 8, Depth: 4, EnclosingFunc: "f"},
+		{Line: 17, Depth: 3, EnclosingFunc: "f"},
+	}, deep)
+	deep, err = FindDeepNesting(p, 4)
+	require.NoError(t, err)
+	assert.Empty(t, deep)
+}
+
+func TestFindComplexSwitchStatements(t *testing.T) {
+	t.Parallel()
+	switches, err := FindComplexSwitchStatements(loadTestdata(t, "switches"), 2)
+	require.NoError(t, err)
+	for i := range switches {
+		switches[i].File = ""
+	}
+	assert.Equal(t, []SwitchInfo{
+		{Line: 4, NumCases: 3, Kind: "switch"},
+		{Line: 9, NumCases: 2, Kind: "switch"},
+		{Line: 13, NumCases: 3, Kind: "select"},
+	}, switches)
+}
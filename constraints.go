@@ -0,0 +1,333 @@
+package forklift
+
+import (
+	"go/ast"
+	"go/build/constraint"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// ParseBuildConstraints returns the build constraint expression of the file named filename,
+// like "linux && amd64", or empty if there is none.
+// The //go:build line is used if there is one;
+// otherwise, the // +build lines are combined and converted to a //go:build expression.
+func ParseBuildConstraints(filename string) (string, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), filename, nil, parser.PackageClauseOnly|parser.ParseComments)
+	if err != nil {
+		return "", err
+	}
+	var plus constraint.Expr
+	for _, g := range f.Comments {
+		if g.Pos() > f.Package {
+			break
+		}
+		for _, c := range g.List {
+			switch {
+			case constraint.IsGoBuild(c.Text):
+				if _, err := constraint.Parse(c.Text); err != nil {
+					return "", err
+				}
+				return strings.TrimSpace(strings.TrimPrefix(c.Text, "//go:build")), nil
+			case constraint.IsPlusBuild(c.Text):
+				x, err := constraint.Parse(c.Text)
+				if err != nil {
+					return "", err
+				}
+				if plus == nil {
+					plus = x
+				} else {
+					plus = &constraint.AndExpr{X: plus, Y: x}
+				}
+			}
+		}
+	}
+	if plus == nil {
+		return "", nil
+	}
+	return plus.String(), nil
+}
+
+// EvaluateBuildConstraint returns whether the build constraint expression expr, like "linux && amd64",
+// is satisfied by the build tags that are true in tags.
+// An empty expression is satisfied.
+func EvaluateBuildConstraint(expr string, tags map[string]bool) (bool, error) {
+	if strings.TrimSpace(expr) == "" {
+		return true, nil
+	}
+	x, err := constraint.Parse("//go:build " + expr)
+	if err != nil {
+		return false, err
+	}
+	return x.Eval(func(tag string) bool { return tags[tag] }), nil
+}
+
+// ConstraintConflict is an exported symbol declared in two files of a package with build constraints that can both be satisfied.
+type ConstraintConflict struct {
+	// Symbol is the symbol name. Methods are qualified by their receiver type name.
+	Symbol string
+
+	// File1 is the file name of the first declaration.
+	File1 string
+
+	// File2 is the file name of the second declaration.
+	File2 string
+}
+
+var (
+	knownOS = []string{
+		"aix", "android", "darwin", "dragonfly", "freebsd", "hurd", "illumos", "ios", "js",
+		"linux", "nacl", "netbsd", "openbsd", "plan9", "solaris", "wasip1", "windows", "zos",
+	}
+	unixOS = map[string]bool{
+		"aix": true, "android": true, "darwin": true, "dragonfly": true, "freebsd": true, "hurd": true,
+		"illumos": true, "ios": true, "linux": true, "netbsd": true, "openbsd": true, "solaris": true,
+	}
+	knownArch = []string{
+		"386", "amd64", "amd64p32", "arm", "armbe", "arm64", "arm64be", "loong64", "mips", "mipsle",
+		"mips64", "mips64le", "mips64p32", "mips64p32le", "ppc", "ppc64", "ppc64le", "riscv", "riscv64",
+		"s390", "s390x", "sparc", "sparc64", "wasm",
+	}
+)
+
+// platformTag returns the value of tag for goos and goarch, and whether tag is a platform tag.
+func platformTag(goos, goarch, tag string) (bool, bool) {
+	switch {
+	case tag == "unix":
+		return unixOS[goos], true
+	case slices.Contains(knownOS, tag):
+		return tag == goos || tag == "linux" && goos == "android" || tag == "solaris" && goos == "illumos" || tag == "darwin" && goos == "ios", true
+	case slices.Contains(knownArch, tag):
+		return tag == goarch, true
+	}
+	return false, false
+}
+
+// fileNameConstraint returns the build constraint implied by the GOOS and GOARCH suffixes of the file named name,
+// or nil if there is none.
+func fileNameConstraint(name string) constraint.Expr {
+	name = strings.TrimSuffix(strings.TrimSuffix(filepath.Base(name), ".go"), "_test")
+	i := strings.Index(name, "_")
+	if i < 0 {
+		return nil
+	}
+	parts := strings.Split(name[i+1:], "_")
+	n := len(parts)
+	if n >= 2 && slices.Contains(knownOS, parts[n-2]) && slices.Contains(knownArch, parts[n-1]) {
+		return &constraint.AndExpr{X: &constraint.TagExpr{Tag: parts[n-2]}, Y: &constraint.TagExpr{Tag: parts[n-1]}}
+	}
+	if slices.Contains(knownOS, parts[n-1]) || slices.Contains(knownArch, parts[n-1]) {
+		return &constraint.TagExpr{Tag: parts[n-1]}
+	}
+	return nil
+}
+
+// fileConstraint returns the build constraint of the file named name, including its file name, or nil if there is none.
+func fileConstraint(name string) (constraint.Expr, error) {
+	expr, err := ParseBuildConstraints(name)
+	if err != nil {
+		return nil, err
+	}
+	x := fileNameConstraint(name)
+	if expr != "" {
+		y, err := constraint.Parse("//go:build " + expr)
+		if err != nil {
+			return nil, err
+		}
+		if x == nil {
+			x = y
+		} else {
+			x = &constraint.AndExpr{X: x, Y: y}
+		}
+	}
+	return x, nil
+}
+
+// maxFreeTags is the maximum number of non-platform tags in an expression for [satisfiable].
+const maxFreeTags = 12
+
+// satisfiable returns whether x is true for a known platform and some values of the other tags.
+// If x has more than [maxFreeTags] other tags, it returns true.
+func satisfiable(x constraint.Expr) bool {
+	seen := map[string]bool{}
+	var free []string
+	x.Eval(func(tag string) bool {
+		if _, ok := platformTag("", "", tag); !ok && !seen[tag] {
+			seen[tag] = true
+			free = append(free, tag)
+		}
+		return false
+	})
+	if len(free) > maxFreeTags {
+		return true
+	}
+	for _, goos := range knownOS {
+		for _, goarch := range knownArch {
+			for bits := 0; bits < 1<<len(free); bits++ {
+				ok := x.Eval(func(tag string) bool {
+					if v, ok := platformTag(goos, goarch, tag); ok {
+						return v
+					}
+					return bits&(1<<slices.Index(free, tag)) != 0
+				})
+				if ok {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// FindBuildConstraintConflicts returns the exported symbols declared in multiple files of p
+// with build constraints that can be satisfied together, sorted by symbol.
+// The files are the Go files and the ignored files, except test files.
+// The constraints include the GOOS and GOARCH file name suffixes,
+// and are satisfied by a known GOOS and GOARCH and any values of the other tags.
+// It requires files.
+func FindBuildConstraintConflicts(p *packages.Package) ([]ConstraintConflict, error) {
+	if p == nil {
+		return nil, ErrNotFound
+	}
+	var files []string
+	for _, f := range append(p.GoFiles[:len(p.GoFiles):len(p.GoFiles)], p.IgnoredFiles...) {
+		if strings.HasSuffix(f, ".go") && !strings.HasSuffix(f, "_test.go") {
+			files = append(files, f)
+		}
+	}
+	sort.Strings(files)
+	declared := map[string][]string{}
+	constraints := map[string]constraint.Expr{}
+	fset := token.NewFileSet()
+	for _, name := range files {
+		f, err := parser.ParseFile(fset, name, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		if constraints[name], err = fileConstraint(name); err != nil {
+			return nil, err
+		}
+		for _, d := range f.Decls {
+			switch d := d.(type) {
+			case *ast.FuncDecl:
+				if d.Name.IsExported() {
+					declared[declName(d)] = append(declared[declName(d)], name)
+				}
+			case *ast.GenDecl:
+				for _, s := range d.Specs {
+					switch s := s.(type) {
+					case *ast.TypeSpec:
+						if s.Name.IsExported() {
+							declared[s.Name.Name] = append(declared[s.Name.Name], name)
+						}
+					case *ast.ValueSpec:
+						for _, id := range s.Names {
+							if id.IsExported() {
+								declared[id.Name] = append(declared[id.Name], name)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	symbols := make([]string, 0, len(declared))
+	for symbol := range declared {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	var conflicts []ConstraintConflict
+	for _, symbol := range symbols {
+		fs := declared[symbol]
+		for i := range fs {
+			for j := i + 1; j < len(fs); j++ {
+				x, y := constraints[fs[i]], constraints[fs[j]]
+				switch {
+				case x == nil && y == nil:
+				case x == nil:
+					x = y
+				case y != nil:
+					x = &constraint.AndExpr{X: x, Y: y}
+				}
+				if x == nil || satisfiable(x) {
+					conflicts = append(conflicts, ConstraintConflict{Symbol: symbol, File1: fs[i], File2: fs[j]})
+				}
+			}
+		}
+	}
+	return conflicts, nil
+}
+
+// FilterByBuildTag returns copies of the packages in ps without the files with build constraints,
+// including GOOS and GOARCH file name suffixes, that are not satisfied by tags, like "linux" and "amd64".
+// The "unix" tag is satisfied by the Unix GOOS tags.
+// Packages without any satisfying Go files are excluded.
+// GoFiles, CompiledGoFiles, and Syntax are filtered; type information is not changed.
+// Files excluded when the packages were loaded are not added.
+// It requires files.
+func FilterByBuildTag(ps []*packages.Package, tags []string) ([]*packages.Package, error) {
+	set := map[string]bool{}
+	for _, tag := range tags {
+		set[tag] = true
+	}
+	satisfied := func(tag string) bool {
+		switch tag {
+		case "unix":
+			for _, t := range tags {
+				if unixOS[t] {
+					return true
+				}
+			}
+		case "linux":
+			return set[tag] || set["android"]
+		case "solaris":
+			return set[tag] || set["illumos"]
+		case "darwin":
+			return set[tag] || set["ios"]
+		}
+		return set[tag]
+	}
+	var filtered []*packages.Package
+	for _, p := range ps {
+		if p == nil {
+			return nil, ErrNotFound
+		}
+		excluded := map[string]bool{}
+		var goFiles []string
+		for _, f := range p.GoFiles {
+			x, err := fileConstraint(f)
+			if err != nil {
+				return nil, err
+			}
+			if x == nil || x.Eval(satisfied) {
+				goFiles = append(goFiles, f)
+			} else {
+				excluded[f] = true
+			}
+		}
+		if len(goFiles) == 0 {
+			continue
+		}
+		q := *p
+		q.GoFiles = goFiles
+		q.CompiledGoFiles = nil
+		for _, f := range p.CompiledGoFiles {
+			if !excluded[f] {
+				q.CompiledGoFiles = append(q.CompiledGoFiles, f)
+			}
+		}
+		q.Syntax = nil
+		for _, f := range p.Syntax {
+			if !excluded[p.Fset.File(f.Pos()).Name()] {
+				q.Syntax = append(q.Syntax, f)
+			}
+		}
+		filtered = append(filtered, &q)
+	}
+	return filtered, nil
+}
@@ -0,0 +1,98 @@
+package forklift
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestParseBuildConstraints(t *testing.T) {
+	t.Parallel()
+	for _, test := range []struct {
+		file, want string
+	}{
+		{file: "modern.go", want: "linux && (amd64 || arm64)"},
+		{file: "legacy.go", want: "(darwin || freebsd) && !cgo"},
+		{file: "none.go"},
+	} {
+		test := test
+		t.Run(test.file, func(t *testing.T) {
+			t.Parallel()
+			expr, err := ParseBuildConstraints("testdata/constraints/" + test.file)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, expr)
+		})
+	}
+	_, err := ParseBuildConstraints("testdata/constraints/missing.go")
+	assert.Error(t, err)
+}
+
+func TestEvaluateBuildConstraint(t *testing.T) {
+	t.Parallel()
+	for _, test := range []struct {
+		expr string
+		tags map[string]bool
+		want bool
+	}{
+		{expr: "", want: true},
+		{expr: "linux && (amd64 || arm64)", tags: map[string]bool{"linux": true, "arm64": true}, want: true},
+		{expr: "linux && (amd64 || arm64)", tags: map[string]bool{"linux": true}},
+		{expr: "!cgo", want: true},
+		{expr: "!cgo", tags: map[string]bool{"cgo": true}},
+	} {
+		ok, err := EvaluateBuildConstraint(test.expr, test.tags)
+		require.NoError(t, err)
+		assert.Equal(t, test.want, ok, test.expr)
+	}
+	_, err := EvaluateBuildConstraint("linux &&", nil)
+	assert.Error(t, err)
+}
+
+func TestFindBuildConstraintConflicts(t *testing.T) {
+	t.Parallel()
+	p, err := Loader{Mode: packages.NeedName | packages.NeedFiles}.LoadPackage("./testdata/conflicts")
+	require.NoError(t, err)
+	conflicts, err := FindBuildConstraintConflicts(p)
+	require.NoError(t, err)
+	for i := range conflicts {
+		conflicts[i].File1 = filepath.Base(conflicts[i].File1)
+		conflicts[i].File2 = filepath.Base(conflicts[i].File2)
+	}
+	assert.Equal(t, []ConstraintConflict{
+		{Symbol: "Close", File1: "b.go", File2: "b_linux.go"},
+		{Symbol: "T.String", File1: "h_darwin.go", File2: "h_ios_arm64.go"},
+		{Symbol: "Version", File1: "e.go", File2: "f.go"},
+	}, conflicts)
+}
+
+func TestFilterByBuildTag(t *testing.T) {
+	t.Parallel()
+	races := loadTestdata(t, "races")
+	env := append(os.Environ(), "CGO_ENABLED=1", "GOOS=linux", "GOARCH=amd64")
+	conflicts, err := Loader{Env: env, Mode: packages.NeedName | packages.NeedFiles}.LoadPackage("./testdata/conflicts")
+	require.NoError(t, err)
+	names := func(files []string) []string {
+		var names []string
+		for _, f := range files {
+			names = append(names, filepath.Base(f))
+		}
+		return names
+	}
+	ps, err := FilterByBuildTag([]*packages.Package{races, conflicts}, []string{"linux", "amd64"})
+	require.NoError(t, err)
+	require.Len(t, ps, 2)
+	assert.Equal(t, []string{"races.go"}, names(ps[0].GoFiles))
+	assert.Equal(t, []string{"races.go"}, names(ps[0].CompiledGoFiles))
+	require.Len(t, ps[0].Syntax, 1)
+	assert.Equal(t, "races.go", filepath.Base(races.Fset.File(ps[0].Syntax[0].Pos()).Name()))
+	assert.Len(t, races.GoFiles, 2)
+	assert.Equal(t, []string{"a_linux.go", "b.go", "b_linux.go", "g.go"}, names(ps[1].GoFiles))
+	ps, err = FilterByBuildTag([]*packages.Package{races}, []string{"go1.21"})
+	require.NoError(t, err)
+	require.Len(t, ps, 1)
+	assert.Equal(t, []string{"old.go", "races.go"}, names(ps[0].GoFiles))
+}
@@ -0,0 +1,307 @@
+package forklift
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// isStdlib returns whether path is the import path of a standard library package.
+func isStdlib(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
+
+// ComputeAfferentCoupling returns the number of packages in all that import target.
+// It requires imports.
+func ComputeAfferentCoupling(target string, all []*packages.Package) int {
+	var n int
+	for _, p := range all {
+		for _, dep := range p.Imports {
+			if dep.PkgPath == target {
+				n++
+				break
+			}
+		}
+	}
+	return n
+}
+
+// ComputeEfferentCoupling returns the number of packages p imports,
+// excluding standard library packages if excludeStdlib.
+// It requires imports.
+func ComputeEfferentCoupling(p *packages.Package, excludeStdlib bool) int {
+	var n int
+	for _, dep := range p.Imports {
+		if !excludeStdlib || !isStdlib(dep.PkgPath) {
+			n++
+		}
+	}
+	return n
+}
+
+// SCC is a strongly connected component of an import graph.
+type SCC struct {
+	// Packages is the sorted package import paths.
+	Packages []string
+
+	// Edges is the sorted imports between the packages, like "a -> b".
+	Edges []string
+}
+
+// FindCyclicPackages returns the strongly connected components of the import graph of ps
+// with more than one package, sorted by their first package.
+// Imports of packages not in ps are ignored.
+// Packages are identified by import path, so test variants of a package are the same package.
+// It requires imports.
+func FindCyclicPackages(ps []*packages.Package) ([]SCC, error) {
+	imports := map[string]map[string]bool{}
+	var paths []string
+	for _, p := range ps {
+		if p == nil {
+			return nil, ErrNotFound
+		}
+		if _, ok := imports[p.PkgPath]; !ok {
+			imports[p.PkgPath] = map[string]bool{}
+			paths = append(paths, p.PkgPath)
+		}
+		for _, dep := range p.Imports {
+			imports[p.PkgPath][dep.PkgPath] = true
+		}
+	}
+	// Tarjan's algorithm.
+	var (
+		index    int
+		indexes  = map[string]int{}
+		lowlinks = map[string]int{}
+		onStack  = map[string]bool{}
+		stack    []string
+		sccs     []SCC
+		connect  func(string)
+	)
+	connect = func(v string) {
+		indexes[v] = index
+		lowlinks[v] = index
+		index++
+		stack = append(stack, v)
+		onStack[v] = true
+		for w := range imports[v] {
+			if _, ok := imports[w]; !ok {
+				continue
+			}
+			if _, ok := indexes[w]; !ok {
+				connect(w)
+				lowlinks[v] = min(lowlinks[v], lowlinks[w])
+			} else if onStack[w] {
+				lowlinks[v] = min(lowlinks[v], indexes[w])
+			}
+		}
+		if lowlinks[v] != indexes[v] {
+			return
+		}
+		var members []string
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			members = append(members, w)
+			if w == v {
+				break
+			}
+		}
+		if len(members) < 2 {
+			return
+		}
+		member := map[string]bool{}
+		for _, m := range members {
+			member[m] = true
+		}
+		var edges []string
+		for _, m := range members {
+			for dep := range imports[m] {
+				if member[dep] {
+					edges = append(edges, m+" -> "+dep)
+				}
+			}
+		}
+		sort.Strings(members)
+		sort.Strings(edges)
+		sccs = append(sccs, SCC{Packages: members, Edges: edges})
+	}
+	for _, path := range paths {
+		if _, ok := indexes[path]; !ok {
+			connect(path)
+		}
+	}
+	sort.Slice(sccs, func(i, j int) bool { return sccs[i].Packages[0] < sccs[j].Packages[0] })
+	return sccs, nil
+}
+
+// ErrNoCoupling means the package has no afferent or efferent coupling.
+var ErrNoCoupling = fmt.Errorf("package has no coupling")
+
+// ComputeInstability returns the instability of p, which is Ce / (Ca + Ce),
+// where Ca is the afferent coupling of p within all,
+// and Ce is the efferent coupling of p excluding standard library packages.
+// It returns [ErrNoCoupling] if Ca and Ce are zero.
+// It requires imports.
+func ComputeInstability(p *packages.Package, all []*packages.Package) (float64, error) {
+	if p == nil {
+		return 0, ErrNotFound
+	}
+	ca := ComputeAfferentCoupling(p.PkgPath, all)
+	ce := ComputeEfferentCoupling(p, true)
+	if ca+ce == 0 {
+		return 0, ErrNoCoupling
+	}
+	return float64(ce) / float64(ca+ce), nil
+}
+
+// ErrNoTypes means the package has no named types.
+var ErrNoTypes = fmt.Errorf("package has no named types")
+
+// ComputeAbstractness returns the abstractness of p, which is the number of interface types
+// divided by the number of named types.
+// It returns [ErrNoTypes] if p has no named types.
+// It requires types.
+func ComputeAbstractness(p *packages.Package) (float64, error) {
+	if err := need(p, packages.NeedTypes); err != nil {
+		return 0, err
+	}
+	var interfaces, total int
+	scope := p.Types.Scope()
+	for _, name := range scope.Names() {
+		t, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || t.IsAlias() {
+			continue
+		}
+		total++
+		if types.IsInterface(t.Type()) {
+			interfaces++
+		}
+	}
+	if total == 0 {
+		return 0, ErrNoTypes
+	}
+	return float64(interfaces) / float64(total), nil
+}
+
+// CallRef is a call of a function declared in another package.
+type CallRef struct {
+	// CallerFunc is the enclosing function declaration name, or empty if none.
+	CallerFunc string
+
+	// CalleeFunc is the called function name. Methods are qualified by their receiver type name.
+	CalleeFunc string
+
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+}
+
+// FindInterPackageDependencies returns the calls in caller of the functions and methods declared in callee.
+// Calls of function values are excluded.
+// It requires syntax and types info for caller, and names for callee.
+func FindInterPackageDependencies(caller, callee *packages.Package) ([]CallRef, error) {
+	if err := need(caller, packages.NeedSyntax|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	if callee == nil {
+		return nil, ErrNotFound
+	}
+	var refs []CallRef
+	inspect(caller, func(n ast.Node, fn string) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		f, ok := typeutil.Callee(caller.TypesInfo, call).(*types.Func)
+		if !ok || f.Pkg() == nil || f.Pkg().Path() != callee.PkgPath {
+			return true
+		}
+		file, line := position(caller, call.Pos())
+		refs = append(refs, CallRef{CallerFunc: fn, CalleeFunc: funcName(f), File: file, Line: line})
+		return true
+	})
+	return refs, nil
+}
+
+// ErrFuncNotFound means the function is not found.
+var ErrFuncNotFound = fmt.Errorf("function not found")
+
+// FanOutKind is a set of kinds of functions.
+type FanOutKind int
+
+const (
+	// FanOutLocal is the functions and methods declared in the package.
+	FanOutLocal FanOutKind = 1 << iota
+
+	// FanOutStdlib is the functions and methods declared in standard library packages.
+	FanOutStdlib
+
+	// FanOutOther is the functions and methods declared in other packages.
+	FanOutOther
+
+	// FanOutAll is all functions and methods.
+	FanOutAll = FanOutLocal | FanOutStdlib | FanOutOther
+)
+
+// ComputeFanOut returns the number of distinct functions and methods
+// used by the function or method named funcName in p, like "F" or "T.M".
+// It returns [ErrFuncNotFound] if the function is not found.
+// It requires syntax and types info.
+func ComputeFanOut(p *packages.Package, funcName string) (int, error) {
+	return ComputeFanOutByKind(p, funcName, FanOutAll)
+}
+
+// ComputeFanOutByKind returns the number of distinct functions and methods of kinds
+// used by the function or method named funcName in p, like "F" or "T.M".
+// Methods of the predeclared error type are standard library methods.
+// It returns [ErrFuncNotFound] if the function is not found.
+// It requires syntax and types info.
+func ComputeFanOutByKind(p *packages.Package, funcName string, kinds FanOutKind) (int, error) {
+	if err := need(p, packages.NeedSyntax|packages.NeedTypesInfo); err != nil {
+		return 0, err
+	}
+	for _, f := range p.Syntax {
+		for _, d := range f.Decls {
+			d, ok := d.(*ast.FuncDecl)
+			if !ok || d.Body == nil || declName(d) != funcName {
+				continue
+			}
+			used := map[*types.Func]bool{}
+			ast.Inspect(d.Body, func(n ast.Node) bool {
+				id, ok := n.(*ast.Ident)
+				if !ok {
+					return true
+				}
+				fn, ok := p.TypesInfo.Uses[id].(*types.Func)
+				if !ok {
+					return true
+				}
+				var kind FanOutKind
+				switch {
+				case fn.Pkg() == p.Types:
+					kind = FanOutLocal
+				case fn.Pkg() == nil || isStdlib(fn.Pkg().Path()):
+					kind = FanOutStdlib
+				default:
+					kind = FanOutOther
+				}
+				if kinds&kind != 0 {
+					used[fn.Origin()] = true
+				}
+				return true
+			})
+			return len(used), nil
+		}
+	}
+	return 0, ErrFuncNotFound
+}
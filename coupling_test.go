@@ -0,0 +1,109 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestComputeAfferentCoupling(t *testing.T) {
+	t.Parallel()
+	all := []*packages.Package{loadTestdata(t, "coupling/a"), loadTestdata(t, "coupling/b")}
+	assert.Equal(t, 1, ComputeAfferentCoupling("github.com/willfaught/forklift/testdata/coupling/b", all))
+	assert.Equal(t, 0, ComputeAfferentCoupling("github.com/willfaught/forklift/testdata/coupling/a", all))
+	assert.Equal(t, 1, ComputeAfferentCoupling("fmt", all))
+}
+
+func TestComputeEfferentCoupling(t *testing.T) {
+	t.Parallel()
+	p := loadTestdata(t, "coupling/a")
+	assert.Equal(t, 2, ComputeEfferentCoupling(p, false))
+	assert.Equal(t, 1, ComputeEfferentCoupling(p, true))
+}
+
+func TestComputeInstability(t *testing.T) {
+	t.Parallel()
+	a, b := loadTestdata(t, "coupling/a"), loadTestdata(t, "coupling/b")
+	all := []*packages.Package{a, b}
+	i, err := ComputeInstability(a, all)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, i)
+	i, err = ComputeInstability(b, all)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, i)
+	_, err = ComputeInstability(b, nil)
+	assert.Equal(t, ErrNoCoupling, err)
+}
+
+func TestComputeAbstractness(t *testing.T) {
+	t.Parallel()
+	a, err := ComputeAbstractness(loadTestdata(t, "coupling/b"))
+	assert.NoError(t, err)
+	assert.InDelta(t, 1.0/3, a, 1e-9)
+	_, err = ComputeAbstractness(loadTestdata(t, "coupling/a"))
+	assert.Equal(t, ErrNoTypes, err)
+}
+
+func TestFindInterPackageDependencies(t *testing.T) {
+	t.Parallel()
+	caller, callee := loadTestdata(t, "calls/caller"), loadTestdata(t, "calls/callee")
+	refs, err := FindInterPackageDependencies(caller, callee)
+	require.NoError(t, err)
+	for i := range refs {
+		refs[i].File = ""
+	}
+	assert.Equal(t, []CallRef{
+		{CalleeFunc: "F", Line: 9},
+		{CallerFunc: "A", CalleeFunc: "T.M", Line: 12},
+		{CallerFunc: "A", CalleeFunc: "F", Line: 12},
+		{CallerFunc: "A", CalleeFunc: "I.N", Line: 13},
+		{CallerFunc: "A", CalleeFunc: "G", Line: 14},
+		{CallerFunc: "S.B", CalleeFunc: "T.P", Line: 20},
+	}, refs)
+	refs, err = FindInterPackageDependencies(callee, caller)
+	require.NoError(t, err)
+	assert.Empty(t, refs)
+}
+
+func TestFindCyclicPackages(t *testing.T) {
+	t.Parallel()
+	ps := map[string]*packages.Package{}
+	for _, path := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		ps[path] = &packages.Package{PkgPath: path, Imports: map[string]*packages.Package{}}
+	}
+	for _, edge := range [][2]string{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"c", "d"}, {"d", "e"}, {"e", "f"}, {"f", "e"}, {"g", "a"}} {
+		ps[edge[0]].Imports[edge[1]] = ps[edge[1]]
+	}
+	ps["a"].Imports["fmt"] = &packages.Package{PkgPath: "fmt"}
+	sccs, err := FindCyclicPackages([]*packages.Package{ps["g"], ps["f"], ps["e"], ps["d"], ps["c"], ps["b"], ps["a"]})
+	require.NoError(t, err)
+	assert.Equal(t, []SCC{
+		{Packages: []string{"a", "b", "c"}, Edges: []string{"a -> b", "b -> c", "c -> a"}},
+		{Packages: []string{"e", "f"}, Edges: []string{"e -> f", "f -> e"}},
+	}, sccs)
+	sccs, err = FindCyclicPackages([]*packages.Package{loadTestdata(t, "coupling/a"), loadTestdata(t, "coupling/b")})
+	require.NoError(t, err)
+	assert.Empty(t, sccs)
+	_, err = FindCyclicPackages([]*packages.Package{nil})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestComputeFanOut(t *testing.T) {
+	t.Parallel()
+	p := loadTestdata(t, "fanout")
+	n, err := ComputeFanOut(p, "F")
+	require.NoError(t, err)
+	assert.Equal(t, 8, n)
+	n, err = ComputeFanOut(p, "Empty")
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+	_, err = ComputeFanOut(p, "G")
+	assert.ErrorIs(t, err, ErrFuncNotFound)
+	for kinds, want := range map[FanOutKind]int{FanOutLocal: 3, FanOutStdlib: 4, FanOutOther: 1, FanOutLocal | FanOutOther: 4} {
+		n, err = ComputeFanOutByKind(p, "F", kinds)
+		require.NoError(t, err)
+		assert.Equal(t, want, n, kinds)
+	}
+}
@@ -0,0 +1,109 @@
+package forklift
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// AnnotatedPackage is a package with coverage information.
+type AnnotatedPackage struct {
+	*packages.Package
+
+	// FunctionCoverage is the percentage of statements covered, from 0 to 100,
+	// keyed by function name qualified by the package path, like "example.com/p.T.M".
+	FunctionCoverage map[string]float64
+}
+
+// coverBlock is a coverage profile block.
+type coverBlock struct {
+	startLine, startCol, endLine, endCol int
+}
+
+// coverCounts is the statement count and whether the block was covered.
+type coverCounts struct {
+	stmts   int
+	covered bool
+}
+
+// readCoverProfile returns the blocks in the coverage profile at name, keyed by file path.
+func readCoverProfile(name string) (map[string]map[coverBlock]coverCounts, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	files := map[string]map[coverBlock]coverCounts{}
+	s := bufio.NewScanner(f)
+	for n := 1; s.Scan(); n++ {
+		line := s.Text()
+		if n == 1 && strings.HasPrefix(line, "mode: ") || line == "" {
+			continue
+		}
+		var b coverBlock
+		var stmts, count int
+		i := strings.LastIndex(line, ":")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid coverage profile line %d: %q", n, line)
+		}
+		if _, err := fmt.Sscanf(line[i+1:], "%d.%d,%d.%d %d %d", &b.startLine, &b.startCol, &b.endLine, &b.endCol, &stmts, &count); err != nil {
+			return nil, fmt.Errorf("invalid coverage profile line %d: %q", n, line)
+		}
+		file := line[:i]
+		if files[file] == nil {
+			files[file] = map[coverBlock]coverCounts{}
+		}
+		c := files[file][b]
+		files[file][b] = coverCounts{stmts: stmts, covered: c.covered || count > 0}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// AnnotateWithCoverage returns p with the coverage of its functions from the "go test -coverprofile" output file coverprofile.
+// Blocks from multiple runs are merged.
+// It requires syntax and names.
+func AnnotateWithCoverage(p *packages.Package, coverprofile string) (*AnnotatedPackage, error) {
+	if err := need(p, packages.NeedSyntax); err != nil {
+		return nil, err
+	}
+	files, err := readCoverProfile(coverprofile)
+	if err != nil {
+		return nil, err
+	}
+	coverage := map[string]float64{}
+	for _, f := range p.Syntax {
+		blocks := files[path.Join(p.PkgPath, filepath.Base(p.Fset.File(f.Pos()).Name()))]
+		for _, d := range f.Decls {
+			d, ok := d.(*ast.FuncDecl)
+			if !ok || d.Body == nil {
+				continue
+			}
+			start, end := p.Fset.Position(d.Pos()), p.Fset.Position(d.End())
+			var covered, total int
+			for b, c := range blocks {
+				if (b.startLine > start.Line || b.startLine == start.Line && b.startCol >= start.Column) &&
+					(b.endLine < end.Line || b.endLine == end.Line && b.endCol <= end.Column) {
+					total += c.stmts
+					if c.covered {
+						covered += c.stmts
+					}
+				}
+			}
+			var percent float64
+			if total > 0 {
+				percent = 100 * float64(covered) / float64(total)
+			}
+			coverage[p.PkgPath+"."+declName(d)] = percent
+		}
+	}
+	return &AnnotatedPackage{Package: p, FunctionCoverage: coverage}, nil
+}
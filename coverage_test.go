@@ -0,0 +1,24 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnotateWithCoverage(t *testing.T) {
+	t.Parallel()
+	p := loadTestdata(t, "coverage")
+	a, err := AnnotateWithCoverage(p, "testdata/coverage/cover.out")
+	require.NoError(t, err)
+	assert.Same(t, p, a.Package)
+	const prefix = "github.com/willfaught/forklift/testdata/coverage."
+	assert.InDeltaMapValues(t, map[string]float64{
+		prefix + "Abs":       200.0 / 3,
+		prefix + "T.M":       0,
+		prefix + "Uncovered": 0,
+	}, a.FunctionCoverage, 0.001)
+	_, err = AnnotateWithCoverage(p, "testdata/coverage/missing.out")
+	assert.Error(t, err)
+}
@@ -0,0 +1,171 @@
+package forklift
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// isContext returns whether t is context.Context.
+func isContext(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "context" && named.Obj().Name() == "Context"
+}
+
+// ContextDropsite is a call that does not pass on the context of the enclosing function.
+type ContextDropsite struct {
+	// FuncName is the enclosing function declaration name.
+	FuncName string
+
+	// CalleeName is the called function name, or the call expression if it cannot be resolved.
+	CalleeName string
+
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+}
+
+// FindContextPropagation returns the calls in functions with a context.Context parameter
+// that pass a context.Context argument that does not refer to a context.Context variable,
+// like context.Background() or nil.
+// It requires syntax and types info.
+func FindContextPropagation(p *packages.Package) ([]ContextDropsite, error) {
+	if err := need(p, packages.NeedSyntax|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	var drops []ContextDropsite
+	for _, f := range p.Syntax {
+		for _, d := range f.Decls {
+			d, ok := d.(*ast.FuncDecl)
+			if !ok || d.Body == nil || !hasContextParam(p, d.Type) {
+				continue
+			}
+			ast.Inspect(d.Body, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				t := p.TypesInfo.TypeOf(call.Fun)
+				if t == nil {
+					return true
+				}
+				sig, ok := t.Underlying().(*types.Signature)
+				if !ok {
+					return true
+				}
+				for i, arg := range call.Args {
+					if i >= sig.Params().Len() || !isContext(sig.Params().At(i).Type()) || refersToContext(p, arg) {
+						continue
+					}
+					callee := types.ExprString(call.Fun)
+					if fn, ok := typeutil.Callee(p.TypesInfo, call).(*types.Func); ok {
+						callee = funcName(fn)
+					}
+					file, line := position(p, call.Pos())
+					drops = append(drops, ContextDropsite{FuncName: declName(d), CalleeName: callee, File: file, Line: line})
+					break
+				}
+				return true
+			})
+		}
+	}
+	return drops, nil
+}
+
+// hasContextParam returns whether t has a context.Context parameter.
+func hasContextParam(p *packages.Package, t *ast.FuncType) bool {
+	for _, field := range t.Params.List {
+		if isContext(p.TypesInfo.TypeOf(field.Type)) {
+			return true
+		}
+	}
+	return false
+}
+
+// refersToContext returns whether e refers to a context.Context variable.
+func refersToContext(p *packages.Package, e ast.Expr) bool {
+	var found bool
+	ast.Inspect(e, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok {
+			if v, ok := p.TypesInfo.Uses[id].(*types.Var); ok && isContext(v.Type()) {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// ContextViolation is a violation of the context.Context conventions.
+type ContextViolation struct {
+	// FuncName is the function declaration name, or the type name for struct fields.
+	FuncName string
+
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+
+	// Issue describes the violation.
+	Issue string
+}
+
+// FindContextFirstParam returns the exported functions and methods with a context.Context parameter
+// that is not the first parameter, the functions with a *context.Context parameter,
+// and the struct types with a context.Context or *context.Context field, in source order.
+// It requires syntax and types info.
+func FindContextFirstParam(p *packages.Package) ([]ContextViolation, error) {
+	if err := need(p, packages.NeedSyntax|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	var violations []ContextViolation
+	add := func(name string, pos token.Pos, issue string) {
+		file, line := position(p, pos)
+		violations = append(violations, ContextViolation{FuncName: name, File: file, Line: line, Issue: issue})
+	}
+	for _, f := range p.Syntax {
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncDecl:
+				fn, _ := p.TypesInfo.Defs[n.Name].(*types.Func)
+				exported := fn != nil && fn.Exported() && exportedRecv(fn)
+				var i int
+				for _, field := range n.Type.Params.List {
+					t := p.TypesInfo.TypeOf(field.Type)
+					if ptr, ok := t.(*types.Pointer); ok && isContext(ptr.Elem()) {
+						add(declName(n), field.Pos(), "context is passed by pointer")
+					} else if isContext(t) && i > 0 && exported {
+						add(declName(n), field.Pos(), "context is not the first parameter")
+					}
+					if len(field.Names) == 0 {
+						i++
+					} else {
+						i += len(field.Names)
+					}
+				}
+			case *ast.TypeSpec:
+				s, ok := n.Type.(*ast.StructType)
+				if !ok {
+					return true
+				}
+				for _, field := range s.Fields.List {
+					t := p.TypesInfo.TypeOf(field.Type)
+					if ptr, ok := t.(*types.Pointer); ok {
+						t = ptr.Elem()
+					}
+					if isContext(t) {
+						add(n.Name.Name, field.Pos(), "context is stored in a struct field")
+					}
+				}
+			}
+			return true
+		})
+	}
+	return violations, nil
+}
@@ -0,0 +1,38 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindContextPropagation(t *testing.T) {
+	t.Parallel()
+	drops, err := FindContextPropagation(loadTestdata(t, "ctxprop"))
+	require.NoError(t, err)
+	for i := range drops {
+		drops[i].File = ""
+	}
+	assert.Equal(t, []ContextDropsite{
+		{FuncName: "Bad", CalleeName: "use", Line: 23},
+		{FuncName: "Bad", CalleeName: "S.Do", Line: 24},
+		{FuncName: "Bad", CalleeName: "f", Line: 25},
+	}, drops)
+}
+
+func TestFindContextFirstParam(t *testing.T) {
+	t.Parallel()
+	violations, err := FindContextFirstParam(loadTestdata(t, "ctxprop"))
+	require.NoError(t, err)
+	for i := range violations {
+		violations[i].File = ""
+	}
+	assert.Equal(t, []ContextViolation{
+		{FuncName: "Second", Line: 34, Issue: "context is not the first parameter"},
+		{FuncName: "Pointer", Line: 38, Issue: "context is passed by pointer"},
+		{FuncName: "S.Third", Line: 40, Issue: "context is not the first parameter"},
+		{FuncName: "Holder", Line: 43, Issue: "context is stored in a struct field"},
+		{FuncName: "holder", Line: 47, Issue: "context is stored in a struct field"},
+	}, violations)
+}
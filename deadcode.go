@@ -0,0 +1,94 @@
+package forklift
+
+import (
+	"go/types"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// DeadSymbol is an unexported declaration that is never referenced.
+type DeadSymbol struct {
+	// Name is the symbol name. Methods are qualified by their receiver type name.
+	Name string
+
+	// Kind is "const", "func", "method", "type", or "var".
+	Kind string
+
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+}
+
+// FindDeadCode returns the unexported declarations in p that are never referenced in p.
+// Init functions, blank identifiers, and methods that may implement an interface are excluded.
+// It requires types and types info.
+func FindDeadCode(p *packages.Package) ([]DeadSymbol, error) {
+	if err := need(p, packages.NeedTypes|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	used := map[types.Object]bool{}
+	for _, obj := range p.TypesInfo.Uses {
+		used[obj] = true
+		switch obj := obj.(type) {
+		case *types.Func:
+			used[obj.Origin()] = true
+		case *types.Var:
+			used[obj.Origin()] = true
+		}
+	}
+	ifaceMethods := map[string]bool{}
+	for _, tv := range p.TypesInfo.Types {
+		if tv.Type == nil {
+			continue
+		}
+		if i, ok := tv.Type.Underlying().(*types.Interface); ok {
+			for j := 0; j < i.NumMethods(); j++ {
+				ifaceMethods[i.Method(j).Name()] = true
+			}
+		}
+	}
+	scope := p.Types.Scope()
+	var dead []DeadSymbol
+	for id, obj := range p.TypesInfo.Defs {
+		if obj == nil || obj.Exported() || obj.Name() == "_" || used[obj] {
+			continue
+		}
+		kind, name := "", obj.Name()
+		switch obj := obj.(type) {
+		case *types.Const:
+			kind = "const"
+		case *types.TypeName:
+			kind = "type"
+		case *types.Var:
+			kind = "var"
+		case *types.Func:
+			sig := obj.Type().(*types.Signature)
+			if sig.Recv() == nil {
+				if name == "init" {
+					continue
+				}
+				kind = "func"
+				break
+			}
+			if types.IsInterface(sig.Recv().Type()) || ifaceMethods[name] {
+				continue
+			}
+			kind, name = "method", funcName(obj)
+		}
+		if kind == "" || kind != "method" && obj.Parent() != scope {
+			continue
+		}
+		file, line := position(p, id.Pos())
+		dead = append(dead, DeadSymbol{Name: name, Kind: kind, File: file, Line: line})
+	}
+	sort.Slice(dead, func(i, j int) bool {
+		if dead[i].File != dead[j].File {
+			return dead[i].File < dead[j].File
+		}
+		return dead[i].Line < dead[j].Line
+	})
+	return dead, nil
+}
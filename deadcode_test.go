@@ -0,0 +1,24 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindDeadCode(t *testing.T) {
+	t.Parallel()
+	dead, err := FindDeadCode(loadTestdata(t, "deadcode"))
+	require.NoError(t, err)
+	for i := range dead {
+		dead[i].File = ""
+	}
+	assert.Equal(t, []DeadSymbol{
+		{Name: "used.unused", Kind: "method", Line: 11},
+		{Name: "unused", Kind: "type", Line: 13},
+		{Name: "unusedConst", Kind: "const", Line: 15},
+		{Name: "unusedVar", Kind: "var", Line: 17},
+		{Name: "unusedFunc", Kind: "func", Line: 23},
+	}, dead)
+}
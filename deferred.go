@@ -0,0 +1,50 @@
+package forklift
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// DeferInfo is a defer statement.
+type DeferInfo struct {
+	// CalleeName is the deferred function name, or empty if it cannot be resolved.
+	CalleeName string
+
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+
+	// EnclosingFunc is the enclosing function declaration name, or empty if none.
+	EnclosingFunc string
+}
+
+// FindDeferredCalls returns the defer statements in p.
+// It requires syntax and types info.
+func FindDeferredCalls(p *packages.Package) ([]DeferInfo, error) {
+	if err := need(p, packages.NeedSyntax|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	var defers []DeferInfo
+	inspect(p, func(n ast.Node, fn string) bool {
+		d, ok := n.(*ast.DeferStmt)
+		if !ok {
+			return true
+		}
+		var callee string
+		switch obj := typeutil.Callee(p.TypesInfo, d.Call).(type) {
+		case *types.Func:
+			callee = funcName(obj)
+		case *types.Builtin:
+			callee = obj.Name()
+		}
+		file, line := position(p, d.Pos())
+		defers = append(defers, DeferInfo{CalleeName: callee, File: file, Line: line, EnclosingFunc: fn})
+		return true
+	})
+	return defers, nil
+}
@@ -0,0 +1,21 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindDeferredCalls(t *testing.T) {
+	t.Parallel()
+	defers, err := FindDeferredCalls(loadTestdata(t, "deferred"))
+	require.NoError(t, err)
+	require.Len(t, defers, 3)
+	assert.Equal(t, "Mutex.Unlock", defers[0].CalleeName)
+	assert.Equal(t, "T.f", defers[0].EnclosingFunc)
+	assert.Equal(t, 11, defers[0].Line)
+	assert.Equal(t, "", defers[1].CalleeName)
+	assert.Equal(t, "println", defers[2].CalleeName)
+	assert.Equal(t, "g", defers[2].EnclosingFunc)
+}
@@ -0,0 +1,129 @@
+package forklift
+
+import (
+	"go/types"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// PromotionConflict is a method promoted from multiple embedded fields of a struct type,
+// which hides the method.
+type PromotionConflict struct {
+	// TypeName is the struct type name.
+	TypeName string
+
+	// MethodName is the method name.
+	MethodName string
+
+	// ConflictingEmbeds is the names of the embedded fields with the method.
+	ConflictingEmbeds []string
+}
+
+// AnalyzeEmbeddingPromotion returns the methods in p promoted from multiple embedded fields
+// of a struct type at the same shallowest depth, which makes the selector ambiguous,
+// sorted by type name and method name.
+// Methods and fields of the struct type itself, and of shallower embedded fields, shadow them.
+// It requires types.
+func AnalyzeEmbeddingPromotion(p *packages.Package) ([]PromotionConflict, error) {
+	if err := need(p, packages.NeedTypes); err != nil {
+		return nil, err
+	}
+	var conflicts []PromotionConflict
+	scope := p.Types.Scope()
+	for _, name := range scope.Names() {
+		t, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || t.IsAlias() {
+			continue
+		}
+		st, ok := t.Type().Underlying().(*types.Struct)
+		if !ok {
+			continue
+		}
+		candidates := map[string]bool{}
+		for i := 0; i < st.NumFields(); i++ {
+			if f := st.Field(i); f.Embedded() {
+				ft := f.Type()
+				if _, ok := ft.Underlying().(*types.Pointer); !ok && !types.IsInterface(ft) {
+					ft = types.NewPointer(ft)
+				}
+				ms := types.NewMethodSet(ft)
+				for j := 0; j < ms.Len(); j++ {
+					candidates[ms.At(j).Obj().Name()] = true
+				}
+			}
+		}
+		embeds := map[string][]string{}
+		for m := range candidates {
+			// The selector is ambiguous if there is no object but there is an index.
+			if obj, index, _ := types.LookupFieldOrMethod(t.Type(), true, p.Types, m); obj != nil || index == nil {
+				continue
+			}
+			depth := -1
+			for i := 0; i < st.NumFields(); i++ {
+				f := st.Field(i)
+				if !f.Embedded() {
+					continue
+				}
+				_, index, _ := types.LookupFieldOrMethod(f.Type(), true, p.Types, m)
+				if index == nil {
+					continue
+				}
+				if depth == -1 || len(index) < depth {
+					depth = len(index)
+					embeds[m] = nil
+				}
+				if len(index) == depth {
+					embeds[m] = append(embeds[m], f.Name())
+				}
+			}
+		}
+		var methods []string
+		for m, fields := range embeds {
+			if len(fields) > 1 {
+				methods = append(methods, m)
+			}
+		}
+		sort.Strings(methods)
+		for _, m := range methods {
+			conflicts = append(conflicts, PromotionConflict{TypeName: name, MethodName: m, ConflictingEmbeds: embeds[m]})
+		}
+	}
+	return conflicts, nil
+}
+
+// UnexportedEmbed is an unexported type embedded in an exported struct type.
+type UnexportedEmbed struct {
+	// ExportedTypeName is the struct type name.
+	ExportedTypeName string
+
+	// EmbeddedTypeName is the embedded type name.
+	EmbeddedTypeName string
+}
+
+// FindUnexportedEmbeds returns the unexported types, like error or package-level unexported types,
+// embedded in the exported struct types in p, sorted by struct type name.
+// It requires types.
+func FindUnexportedEmbeds(p *packages.Package) ([]UnexportedEmbed, error) {
+	if err := need(p, packages.NeedTypes); err != nil {
+		return nil, err
+	}
+	var embeds []UnexportedEmbed
+	scope := p.Types.Scope()
+	for _, name := range scope.Names() {
+		t, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || t.IsAlias() || !t.Exported() {
+			continue
+		}
+		s, ok := t.Type().Underlying().(*types.Struct)
+		if !ok {
+			continue
+		}
+		for i := 0; i < s.NumFields(); i++ {
+			if f := s.Field(i); f.Embedded() && !f.Exported() {
+				embeds = append(embeds, UnexportedEmbed{ExportedTypeName: name, EmbeddedTypeName: f.Name()})
+			}
+		}
+	}
+	return embeds, nil
+}
@@ -0,0 +1,29 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeEmbeddingPromotion(t *testing.T) {
+	t.Parallel()
+	conflicts, err := AnalyzeEmbeddingPromotion(loadTestdata(t, "embedding"))
+	require.NoError(t, err)
+	assert.Equal(t, []PromotionConflict{
+		{TypeName: "Both", MethodName: "Close", ConflictingEmbeds: []string{"A", "B"}},
+		{TypeName: "Both", MethodName: "Write", ConflictingEmbeds: []string{"Writer", "Builder"}},
+		{TypeName: "Deep", MethodName: "M", ConflictingEmbeds: []string{"D", "G"}},
+	}, conflicts)
+}
+
+func TestFindUnexportedEmbeds(t *testing.T) {
+	t.Parallel()
+	embeds, err := FindUnexportedEmbeds(loadTestdata(t, "embedding"))
+	require.NoError(t, err)
+	assert.Equal(t, []UnexportedEmbed{
+		{ExportedTypeName: "Outer", EmbeddedTypeName: "inner"},
+		{ExportedTypeName: "Outer", EmbeddedTypeName: "error"},
+	}, embeds)
+}
@@ -0,0 +1,217 @@
+package forklift
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// embedPatterns returns the patterns of the //go:embed comment c, and whether c is one.
+func embedPatterns(c *ast.Comment) ([]string, bool) {
+	text, ok := strings.CutPrefix(c.Text, "//go:embed")
+	if !ok || text != "" && text[0] != ' ' && text[0] != '\t' {
+		return nil, false
+	}
+	var patterns []string
+	for text = strings.TrimSpace(text); text != ""; text = strings.TrimSpace(text) {
+		var pattern string
+		switch text[0] {
+		case '"', '`':
+			quoted, err := strconv.QuotedPrefix(text)
+			if err != nil {
+				return append(patterns, text), true
+			}
+			pattern, _ = strconv.Unquote(quoted)
+			text = text[len(quoted):]
+		default:
+			i := strings.IndexAny(text, " \t")
+			if i < 0 {
+				i = len(text)
+			}
+			pattern, text = text[:i], text[i:]
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, true
+}
+
+// inspectEmbeds calls visit for each //go:embed comment in p with the file directory and the variable name,
+// or an empty variable name if it does not precede a variable declaration.
+func inspectEmbeds(p *packages.Package, visit func(c *ast.Comment, dir, varName string, patterns []string)) {
+	for _, f := range p.Syntax {
+		vars := map[*ast.CommentGroup]string{}
+		for _, d := range f.Decls {
+			d, ok := d.(*ast.GenDecl)
+			if !ok || d.Tok != token.VAR {
+				continue
+			}
+			for _, s := range d.Specs {
+				s := s.(*ast.ValueSpec)
+				doc := s.Doc
+				if doc == nil && !d.Lparen.IsValid() {
+					doc = d.Doc
+				}
+				if doc != nil && len(s.Names) > 0 {
+					vars[doc] = s.Names[0].Name
+				}
+			}
+		}
+		dir := filepath.Dir(p.Fset.File(f.Pos()).Name())
+		for _, g := range f.Comments {
+			for _, c := range g.List {
+				if patterns, ok := embedPatterns(c); ok {
+					visit(c, dir, vars[g], patterns)
+				}
+			}
+		}
+	}
+}
+
+// EmbedError is an invalid //go:embed pattern.
+type EmbedError struct {
+	// Pattern is the pattern.
+	Pattern string
+
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+
+	// Message is the problem.
+	Message string
+}
+
+// FindEmbedPatternErrors returns the invalid //go:embed patterns in p.
+// Patterns are invalid if they use backslashes, have "." or ".." elements or other invalid syntax,
+// match no files, or match files in another module.
+// The patterns are read from the syntax, since packages with invalid patterns cannot be loaded with [Loader].
+// It requires syntax.
+func FindEmbedPatternErrors(p *packages.Package) ([]EmbedError, error) {
+	if err := need(p, packages.NeedSyntax); err != nil {
+		return nil, err
+	}
+	var errs []EmbedError
+	inspectEmbeds(p, func(c *ast.Comment, dir, _ string, patterns []string) {
+		file, line := position(p, c.Pos())
+		for _, pattern := range patterns {
+			if message := embedPatternError(dir, pattern); message != "" {
+				errs = append(errs, EmbedError{Pattern: pattern, File: file, Line: line, Message: message})
+			}
+		}
+	})
+	return errs, nil
+}
+
+// embedPatternError returns the problem with pattern in dir, or empty if there is none.
+func embedPatternError(dir, pattern string) string {
+	glob := strings.TrimPrefix(pattern, "all:")
+	switch {
+	case strings.Contains(glob, `\`):
+		return "invalid path separator: use /"
+	case glob == ".." || strings.HasPrefix(glob, "../") || strings.HasSuffix(glob, "/..") || strings.Contains(glob, "/../"):
+		return "invalid path: .. elements are not allowed"
+	case !fs.ValidPath(glob) || glob == ".":
+		return "invalid pattern syntax"
+	}
+	matches, err := filepath.Glob(filepath.Join(dir, filepath.FromSlash(glob)))
+	if err != nil {
+		return "invalid pattern syntax"
+	}
+	if len(matches) == 0 {
+		return "no matching files found"
+	}
+	for _, m := range matches {
+		for d := m; len(d) > len(dir); d = filepath.Dir(d) {
+			if _, err := os.Stat(filepath.Join(d, "go.mod")); err == nil {
+				return fmt.Sprintf("cannot embed %s: in different module", filepath.ToSlash(m[len(dir)+1:]))
+			}
+		}
+	}
+	return ""
+}
+
+// EmbedEntry is a //go:embed pattern and the files it matches.
+type EmbedEntry struct {
+	// Pattern is the pattern.
+	Pattern string
+
+	// VarName is the name of the variable declaration that follows the comment.
+	VarName string
+
+	// Files is the sorted names of the matched files.
+	// The files in matched directories are included,
+	// except those with names that begin with "." or "_", unless the pattern begins with "all:".
+	Files []string
+}
+
+// ListEmbedFiles returns the //go:embed patterns in p and the files they match.
+// It requires syntax.
+func ListEmbedFiles(p *packages.Package) ([]EmbedEntry, error) {
+	if err := need(p, packages.NeedSyntax); err != nil {
+		return nil, err
+	}
+	var entries []EmbedEntry
+	var err error
+	inspectEmbeds(p, func(c *ast.Comment, dir, varName string, patterns []string) {
+		for _, pattern := range patterns {
+			if err != nil {
+				return
+			}
+			var files []string
+			files, err = embedFiles(dir, pattern)
+			entries = append(entries, EmbedEntry{Pattern: pattern, VarName: varName, Files: files})
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// embedFiles returns the sorted names of the files in dir matched by pattern.
+func embedFiles(dir, pattern string) ([]string, error) {
+	glob, all := strings.CutPrefix(pattern, "all:")
+	matches, err := filepath.Glob(filepath.Join(dir, filepath.FromSlash(glob)))
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, m := range matches {
+		err := filepath.WalkDir(m, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if path != m {
+				if name := d.Name(); !all && (name[0] == '.' || name[0] == '_') {
+					if d.IsDir() {
+						return filepath.SkipDir
+					}
+					return nil
+				}
+				if d.IsDir() {
+					if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+						return filepath.SkipDir
+					}
+				}
+			}
+			if d.Type().IsRegular() {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
@@ -0,0 +1,68 @@
+package forklift
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestFindEmbedPatternErrors(t *testing.T) {
+	t.Parallel()
+	errs, err := FindEmbedPatternErrors(loadTestdata(t, "embedfiles"))
+	require.NoError(t, err)
+	assert.Empty(t, errs)
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filepath.Join("testdata", "embedfiles", "bad.go"), `package embedfiles
+
+//go:embed missing.txt static\a.txt ../hello.txt
+var a string
+
+//go:embed static/./a.txt "nested/x.txt" nested hello.txt
+var b string
+`, parser.ParseComments)
+	require.NoError(t, err)
+	errs, err = FindEmbedPatternErrors(&packages.Package{Fset: fset, Syntax: []*ast.File{f}})
+	require.NoError(t, err)
+	for i := range errs {
+		errs[i].File = ""
+	}
+	assert.Equal(t, []EmbedError{
+		{Pattern: "missing.txt", Line: 3, Message: "no matching files found"},
+		{Pattern: `static\a.txt`, Line: 3, Message: "invalid path separator: use /"},
+		{Pattern: "../hello.txt", Line: 3, Message: "invalid path: .. elements are not allowed"},
+		{Pattern: "static/./a.txt", Line: 6, Message: "invalid pattern syntax"},
+		{Pattern: "nested/x.txt", Line: 6, Message: "cannot embed nested/x.txt: in different module"},
+		{Pattern: "nested", Line: 6, Message: "cannot embed nested: in different module"},
+	}, errs)
+}
+
+func TestListEmbedFiles(t *testing.T) {
+	t.Parallel()
+	p := loadTestdata(t, "embedfiles")
+	entries, err := ListEmbedFiles(p)
+	require.NoError(t, err)
+	dir, err := filepath.Abs(filepath.Join("testdata", "embedfiles"))
+	require.NoError(t, err)
+	var all []string
+	for i := range entries {
+		all = append(all, entries[i].Files...)
+		for j, f := range entries[i].Files {
+			entries[i].Files[j], err = filepath.Rel(dir, f)
+			require.NoError(t, err)
+		}
+	}
+	assert.Equal(t, []EmbedEntry{
+		{Pattern: "hello.txt", VarName: "hello", Files: []string{"hello.txt"}},
+		{Pattern: "static/*.txt", VarName: "files", Files: []string{"static/a.txt", "static/b.txt"}},
+		{Pattern: "quoted name.txt", VarName: "files", Files: []string{"quoted name.txt"}},
+		{Pattern: "static", VarName: "static", Files: []string{"static/a.txt", "static/b.txt"}},
+	}, entries)
+	assert.Subset(t, p.EmbedFiles, all)
+	assert.Subset(t, all, p.EmbedFiles)
+}
@@ -0,0 +1,81 @@
+package forklift
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// IotaGroup is a constant declaration that uses iota.
+type IotaGroup struct {
+	// TypeName is the type name of the first constant, or empty if it is untyped or unnamed.
+	TypeName string
+
+	// Constants is the constants, excluding blank ones.
+	Constants []IotaConst
+}
+
+// IotaConst is a constant in an [IotaGroup].
+type IotaConst struct {
+	// Name is the constant name.
+	Name string
+
+	// Value is the constant value.
+	Value int64
+}
+
+// FindIotaSequences returns the constant declarations in p that use iota.
+// Constants with values that are not int64 integers are excluded.
+// It requires syntax, types, and types info.
+func FindIotaSequences(p *packages.Package) ([]IotaGroup, error) {
+	if err := need(p, packages.NeedSyntax|packages.NeedTypes|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	var groups []IotaGroup
+	qualifier := types.RelativeTo(p.Types)
+	for _, f := range p.Syntax {
+		for _, d := range f.Decls {
+			d, ok := d.(*ast.GenDecl)
+			if !ok || d.Tok != token.CONST || !usesIota(p, d) {
+				continue
+			}
+			var g IotaGroup
+			for i, s := range d.Specs {
+				for _, id := range s.(*ast.ValueSpec).Names {
+					c, ok := p.TypesInfo.Defs[id].(*types.Const)
+					if !ok {
+						continue
+					}
+					if i == 0 {
+						if named, ok := c.Type().(*types.Named); ok {
+							g.TypeName = types.TypeString(named, qualifier)
+						}
+					}
+					if id.Name == "_" {
+						continue
+					}
+					if v, exact := constant.Int64Val(constant.ToInt(c.Val())); exact {
+						g.Constants = append(g.Constants, IotaConst{Name: id.Name, Value: v})
+					}
+				}
+			}
+			groups = append(groups, g)
+		}
+	}
+	return groups, nil
+}
+
+// usesIota returns whether d refers to iota.
+func usesIota(p *packages.Package, d *ast.GenDecl) bool {
+	var found bool
+	ast.Inspect(d, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && p.TypesInfo.Uses[id] == types.Universe.Lookup("iota") {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
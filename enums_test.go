@@ -0,0 +1,20 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindIotaSequences(t *testing.T) {
+	t.Parallel()
+	groups, err := FindIotaSequences(loadTestdata(t, "enums"))
+	require.NoError(t, err)
+	assert.Equal(t, []IotaGroup{
+		{TypeName: "Color", Constants: []IotaConst{{"Red", 0}, {"Green", 1}, {"Blue", 2}}},
+		{Constants: []IotaConst{{"KB", 1024}, {"MB", 1048576}}},
+		{Constants: []IotaConst{{"Single", 0}}},
+		{TypeName: "Level", Constants: []IotaConst{{"Debug", 1}, {"Info", 2}, {"Warn", 12}}},
+	}, groups)
+}
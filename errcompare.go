@@ -0,0 +1,50 @@
+package forklift
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// ErrCompare is a comparison of error values with == or !=.
+type ErrCompare struct {
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+
+	// ErrExpr is the comparison expression, like "err == io.EOF".
+	ErrExpr string
+}
+
+// FindRawErrorComparisons returns the comparisons in p with == or != where an operand
+// implements error, which do not match wrapped errors like errors.Is does.
+// Comparisons with nil are excluded.
+// It requires syntax and types info.
+func FindRawErrorComparisons(p *packages.Package) ([]ErrCompare, error) {
+	if err := need(p, packages.NeedSyntax|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	var compares []ErrCompare
+	for _, f := range p.Syntax {
+		ast.Inspect(f, func(n ast.Node) bool {
+			b, ok := n.(*ast.BinaryExpr)
+			if !ok || b.Op != token.EQL && b.Op != token.NEQ {
+				return true
+			}
+			if p.TypesInfo.Types[b.X].IsNil() || p.TypesInfo.Types[b.Y].IsNil() {
+				return true
+			}
+			if !isError(p.TypesInfo.TypeOf(b.X)) && !isError(p.TypesInfo.TypeOf(b.Y)) {
+				return true
+			}
+			file, line := position(p, b.Pos())
+			compares = append(compares, ErrCompare{File: file, Line: line, ErrExpr: types.ExprString(b)})
+			return true
+		})
+	}
+	return compares, nil
+}
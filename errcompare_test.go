@@ -0,0 +1,21 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindRawErrorComparisons(t *testing.T) {
+	t.Parallel()
+	compares, err := FindRawErrorComparisons(loadTestdata(t, "errcompare"))
+	require.NoError(t, err)
+	for i := range compares {
+		compares[i].File = ""
+	}
+	assert.Equal(t, []ErrCompare{
+		{Line: 10, ErrExpr: "err != io.EOF"},
+		{Line: 16, ErrExpr: "io.ErrClosedPipe == err"},
+	}, compares)
+}
@@ -0,0 +1,121 @@
+package forklift
+
+import (
+	"go/ast"
+	"go/constant"
+	"strconv"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// ErrorFmtIssue is a call to fmt.Errorf that formats an error with %v.
+type ErrorFmtIssue struct {
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+
+	// FormatString is the format string.
+	FormatString string
+}
+
+// formatVerbs returns the verbs in format and the indexes of the arguments they format,
+// starting at zero.
+func formatVerbs(format string) (verbs []rune, args []int) {
+	var arg int
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		i++
+		for i < len(format) && (format[i] == '+' || format[i] == '-' || format[i] == '#' || format[i] == ' ' || format[i] == '0') {
+			i++
+		}
+		index := func() {
+			if i >= len(format) || format[i] != '[' {
+				return
+			}
+			j := i + 1
+			for j < len(format) && format[j] != ']' {
+				j++
+			}
+			if j < len(format) {
+				if n, err := strconv.Atoi(format[i+1 : j]); err == nil && n > 0 {
+					arg = n - 1
+				}
+				i = j + 1
+			}
+		}
+		number := func() {
+			index()
+			if i < len(format) && format[i] == '*' {
+				arg++
+				i++
+				return
+			}
+			for i < len(format) && format[i] >= '0' && format[i] <= '9' {
+				i++
+			}
+		}
+		number()
+		if i < len(format) && format[i] == '.' {
+			i++
+			number()
+		}
+		index()
+		if i >= len(format) {
+			break
+		}
+		verb, size := utf8.DecodeRuneInString(format[i:])
+		i += size - 1
+		if verb == '%' {
+			continue
+		}
+		verbs = append(verbs, verb)
+		args = append(args, arg)
+		arg++
+	}
+	return verbs, args
+}
+
+// FindMissingErrorWrapping returns the calls to fmt.Errorf in p that format an error argument
+// with %v instead of %w. Calls with a non-constant format string are excluded.
+// It requires syntax and types info.
+func FindMissingErrorWrapping(p *packages.Package) ([]ErrorFmtIssue, error) {
+	if err := need(p, packages.NeedSyntax|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	var issues []ErrorFmtIssue
+	for _, f := range p.Syntax {
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			fn := typeutil.StaticCallee(p.TypesInfo, call)
+			if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != "fmt" || fn.Name() != "Errorf" {
+				return true
+			}
+			tv := p.TypesInfo.Types[call.Args[0]]
+			if tv.Value == nil || tv.Value.Kind() != constant.String {
+				return true
+			}
+			format := constant.StringVal(tv.Value)
+			verbs, args := formatVerbs(format)
+			for i, verb := range verbs {
+				arg := args[i] + 1
+				if verb != 'v' || arg >= len(call.Args) || !isError(p.TypesInfo.TypeOf(call.Args[arg])) {
+					continue
+				}
+				file, line := position(p, call.Pos())
+				issues = append(issues, ErrorFmtIssue{File: file, Line: line, FormatString: format})
+				break
+			}
+			return true
+		})
+	}
+	return issues, nil
+}
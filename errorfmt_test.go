@@ -0,0 +1,45 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatVerbs(t *testing.T) {
+	t.Parallel()
+	for _, test := range []struct {
+		format string
+		verbs  []rune
+		args   []int
+	}{
+		{format: ""},
+		{format: "100%%"},
+		{format: "%s: %v", verbs: []rune{'s', 'v'}, args: []int{0, 1}},
+		{format: "%-8.3f %x", verbs: []rune{'f', 'x'}, args: []int{0, 1}},
+		{format: "%*d %v", verbs: []rune{'d', 'v'}, args: []int{1, 2}},
+		{format: "%[2]v %[1]s %v", verbs: []rune{'v', 's', 'v'}, args: []int{1, 0, 1}},
+	} {
+		test := test
+		t.Run(test.format, func(t *testing.T) {
+			t.Parallel()
+			verbs, args := formatVerbs(test.format)
+			assert.Equal(t, test.verbs, verbs)
+			assert.Equal(t, test.args, args)
+		})
+	}
+}
+
+func TestFindMissingErrorWrapping(t *testing.T) {
+	t.Parallel()
+	issues, err := FindMissingErrorWrapping(loadTestdata(t, "errorfmt"))
+	require.NoError(t, err)
+	for i := range issues {
+		issues[i].File = ""
+	}
+	assert.Equal(t, []ErrorFmtIssue{
+		{Line: 12, FormatString: "cannot open %s: %v"},
+		{Line: 14, FormatString: "%[2]v %[1]s"},
+	}, issues)
+}
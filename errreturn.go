@@ -0,0 +1,221 @@
+package forklift
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// returnsError returns whether t is or has a result that is an error.
+func returnsError(t types.Type) bool {
+	if tuple, ok := t.(*types.Tuple); ok {
+		for i := 0; i < tuple.Len(); i++ {
+			if isError(tuple.At(i).Type()) {
+				return true
+			}
+		}
+		return false
+	}
+	return isError(t)
+}
+
+// callsErrorFunc returns whether body calls a function with an error result.
+func callsErrorFunc(p *packages.Package, body *ast.BlockStmt) bool {
+	var found bool
+	ast.Inspect(body, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			if tv, ok := p.TypesInfo.Types[call]; ok && !tv.IsType() && returnsError(tv.Type) {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// exportedFuncDecls calls visit for each exported function and method declaration in p with a body,
+// excluding methods of unexported types.
+func exportedFuncDecls(p *packages.Package, visit func(d *ast.FuncDecl, f *types.Func)) {
+	for _, file := range p.Syntax {
+		for _, d := range file.Decls {
+			d, ok := d.(*ast.FuncDecl)
+			if !ok || d.Body == nil || !d.Name.IsExported() {
+				continue
+			}
+			if f, ok := p.TypesInfo.Defs[d.Name].(*types.Func); ok && exportedRecv(f) {
+				visit(d, f)
+			}
+		}
+	}
+}
+
+// FindFunctionsWithoutErrorReturn returns the names of the exported functions and methods in p
+// that call functions with error results, but do not have error results,
+// excluding methods of types with an Err method, like bufio.Scanner.
+// Methods are qualified by their receiver type name.
+// It requires syntax, types, and types info.
+func FindFunctionsWithoutErrorReturn(p *packages.Package) ([]string, error) {
+	if err := need(p, packages.NeedSyntax|packages.NeedTypes|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	var names []string
+	exportedFuncDecls(p, func(d *ast.FuncDecl, f *types.Func) {
+		sig := f.Type().(*types.Signature)
+		if returnsError(sig.Results()) || !callsErrorFunc(p, d.Body) {
+			return
+		}
+		if recv := sig.Recv(); recv != nil {
+			obj, _, _ := types.LookupFieldOrMethod(recv.Type(), true, f.Pkg(), "Err")
+			if m, ok := obj.(*types.Func); ok {
+				if s := m.Type().(*types.Signature); s.Params().Len() == 0 && s.Results().Len() == 1 && isError(s.Results().At(0).Type()) {
+					return
+				}
+			}
+		}
+		names = append(names, funcName(f))
+	})
+	return names, nil
+}
+
+// FindExportedFunctionsWithoutErrorReturn returns the names of the exported functions and methods in p
+// that call functions with error results, but do not have error results.
+// Unlike [FindFunctionsWithoutErrorReturn], it includes methods of types with an Err method.
+// Methods are qualified by their receiver type name.
+// It requires syntax, types, and types info.
+func FindExportedFunctionsWithoutErrorReturn(p *packages.Package) ([]string, error) {
+	if err := need(p, packages.NeedSyntax|packages.NeedTypes|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	var names []string
+	exportedFuncDecls(p, func(d *ast.FuncDecl, f *types.Func) {
+		if !returnsError(f.Type().(*types.Signature).Results()) && callsErrorFunc(p, d.Body) {
+			names = append(names, funcName(f))
+		}
+	})
+	return names, nil
+}
+
+// FindMissingReturnErrors returns the names of the function and method declarations in p
+// with an error last result that can reach the end of their bodies,
+// because their bodies do not end in a terminating statement, as defined by the Go specification,
+// like an if statement without an else branch.
+// Such functions do not compile, so types are not needed.
+// Methods are qualified by their receiver type name.
+// It requires syntax.
+func FindMissingReturnErrors(p *packages.Package) ([]string, error) {
+	if err := need(p, packages.NeedSyntax); err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, f := range p.Syntax {
+		for _, d := range f.Decls {
+			d, ok := d.(*ast.FuncDecl)
+			if !ok || d.Body == nil || d.Type.Results == nil || len(d.Type.Results.List) == 0 {
+				continue
+			}
+			last, ok := d.Type.Results.List[len(d.Type.Results.List)-1].Type.(*ast.Ident)
+			if ok && last.Name == "error" && !terminatesList(d.Body.List) {
+				names = append(names, declName(d))
+			}
+		}
+	}
+	return names, nil
+}
+
+// terminatesList returns whether the last non-empty statement in stmts is terminating.
+func terminatesList(stmts []ast.Stmt) bool {
+	for i := len(stmts) - 1; i >= 0; i-- {
+		if _, ok := stmts[i].(*ast.EmptyStmt); !ok {
+			return terminates(stmts[i], "")
+		}
+	}
+	return false
+}
+
+// terminates returns whether s with label is a terminating statement.
+func terminates(s ast.Stmt, label string) bool {
+	switch s := s.(type) {
+	case *ast.ReturnStmt:
+		return true
+	case *ast.BranchStmt:
+		return s.Tok == token.GOTO
+	case *ast.ExprStmt:
+		call, ok := ast.Unparen(s.X).(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		id, ok := ast.Unparen(call.Fun).(*ast.Ident)
+		return ok && id.Name == "panic"
+	case *ast.BlockStmt:
+		return terminatesList(s.List)
+	case *ast.IfStmt:
+		return s.Else != nil && terminates(s.Body, "") && terminates(s.Else, "")
+	case *ast.ForStmt:
+		return s.Cond == nil && !hasBreak(s.Body, label, true)
+	case *ast.LabeledStmt:
+		return terminates(s.Stmt, s.Label.Name)
+	case *ast.SwitchStmt:
+		return terminatesClauses(s.Body, label)
+	case *ast.TypeSwitchStmt:
+		return terminatesClauses(s.Body, label)
+	case *ast.SelectStmt:
+		for _, c := range s.Body.List {
+			if !terminatesList(c.(*ast.CommClause).Body) {
+				return false
+			}
+		}
+		return !hasBreak(s.Body, label, true)
+	}
+	return false
+}
+
+// terminatesClauses returns whether the switch statement body with label has a default case,
+// and each case ends in a terminating statement or a fallthrough statement,
+// and there are no break statements for the switch statement.
+func terminatesClauses(body *ast.BlockStmt, label string) bool {
+	var hasDefault bool
+	for _, c := range body.List {
+		c := c.(*ast.CaseClause)
+		if c.List == nil {
+			hasDefault = true
+		}
+		if terminatesList(c.Body) {
+			continue
+		}
+		if n := len(c.Body); n == 0 {
+			return false
+		} else if b, ok := c.Body[n-1].(*ast.BranchStmt); !ok || b.Tok != token.FALLTHROUGH {
+			return false
+		}
+	}
+	return hasDefault && !hasBreak(body, label, true)
+}
+
+// hasBreak returns whether n has a break statement with label,
+// or without a label if unlabeled and not in a nested for, switch, or select statement.
+// Function literals are excluded.
+func hasBreak(n ast.Node, label string, unlabeled bool) bool {
+	var found bool
+	ast.Inspect(n, func(m ast.Node) bool {
+		if found {
+			return false
+		}
+		switch m := m.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.BranchStmt:
+			if m.Tok == token.BREAK && (m.Label == nil && unlabeled || m.Label != nil && m.Label.Name == label) {
+				found = true
+			}
+		case *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+			if unlabeled {
+				found = hasBreak(m, label, false)
+				return false
+			}
+		}
+		return true
+	})
+	return found
+}
@@ -0,0 +1,134 @@
+package forklift
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestFindFunctionsWithoutErrorReturn(t *testing.T) {
+	t.Parallel()
+	names, err := FindFunctionsWithoutErrorReturn(loadTestdata(t, "errreturn"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Remove", "Atoi", "Writer.Flush"}, names)
+}
+
+func TestFindExportedFunctionsWithoutErrorReturn(t *testing.T) {
+	t.Parallel()
+	names, err := FindExportedFunctionsWithoutErrorReturn(loadTestdata(t, "errreturn"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Remove", "Atoi", "Scanner.Scan", "Writer.Flush"}, names)
+}
+
+func TestFindMissingReturnErrors(t *testing.T) {
+	t.Parallel()
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "missing.go", `package missing
+
+func If(b bool) error {
+	if b {
+		return nil
+	}
+}
+
+func IfElse(b bool) (int, error) {
+	if b {
+		return 0, nil
+	} else {
+		return 1, nil
+	}
+}
+
+func ElseIf(b, c bool) error {
+	if b {
+		return nil
+	} else if c {
+		return nil
+	}
+}
+
+func Panic() error { panic("x") }
+
+func Loop() error {
+	for {
+	}
+}
+
+func LoopBreak() error {
+	for {
+		break
+	}
+}
+
+func LabeledBreak() error {
+L:
+	for {
+		switch {
+		case true:
+			break L
+		}
+	}
+}
+
+func NestedBreak() error {
+	for {
+		for {
+			break
+		}
+		func() {
+			for {
+			}
+		}()
+	}
+}
+
+func Switch(n int) error {
+	switch n {
+	case 0:
+		fallthrough
+	case 1:
+		return nil
+	default:
+		panic(n)
+	}
+}
+
+func SwitchNoDefault(n int) error {
+	switch n {
+	case 0:
+		return nil
+	}
+}
+
+func Select(c chan int) error {
+	select {
+	case <-c:
+		return nil
+	}
+}
+
+type T struct{}
+
+func (T) Close() error {
+	_ = 1
+}
+
+func NoError() int {
+	return 0
+}
+
+func Empty() {}
+`, 0)
+	require.NoError(t, err)
+	names, err := FindMissingReturnErrors(&packages.Package{Fset: fset, Syntax: []*ast.File{f}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"If", "ElseIf", "LoopBreak", "LabeledBreak", "SwitchNoDefault", "T.Close"}, names)
+	names, err = FindMissingReturnErrors(loadTestdata(t, "errreturn"))
+	require.NoError(t, err)
+	assert.Empty(t, names)
+}
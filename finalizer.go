@@ -0,0 +1,75 @@
+package forklift
+
+import (
+	"go/ast"
+	"go/types"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// FinalizerUse is a call to runtime.SetFinalizer.
+type FinalizerUse struct {
+	// ObjectType is the type of the object argument.
+	ObjectType string
+
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+}
+
+// FindFinalizers returns the calls to runtime.SetFinalizer in p.
+// It requires syntax and types info.
+func FindFinalizers(p *packages.Package) ([]FinalizerUse, error) {
+	if err := need(p, packages.NeedSyntax|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	var uses []FinalizerUse
+	for _, f := range p.Syntax {
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			fn := typeutil.StaticCallee(p.TypesInfo, call)
+			if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != "runtime" || fn.Name() != "SetFinalizer" {
+				return true
+			}
+			file, line := position(p, call.Pos())
+			uses = append(uses, FinalizerUse{
+				ObjectType: types.TypeString(p.TypesInfo.TypeOf(call.Args[0]), types.RelativeTo(p.Types)),
+				File:       file,
+				Line:       line,
+			})
+			return true
+		})
+	}
+	return uses, nil
+}
+
+// FindFunctionsCallingGC returns the sorted names of the function declarations in p that call runtime.GC.
+// Methods are qualified by their receiver type name.
+// It requires syntax and types info.
+func FindFunctionsCallingGC(p *packages.Package) ([]string, error) {
+	if err := need(p, packages.NeedSyntax|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	var names []string
+	inspect(p, func(n ast.Node, fn string) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || fn == "" || seen[fn] {
+			return true
+		}
+		if f := typeutil.StaticCallee(p.TypesInfo, call); f != nil && f.Pkg() != nil && f.Pkg().Path() == "runtime" && f.Name() == "GC" {
+			seen[fn] = true
+			names = append(names, fn)
+		}
+		return true
+	})
+	sort.Strings(names)
+	return names, nil
+}
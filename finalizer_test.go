@@ -0,0 +1,24 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindFinalizers(t *testing.T) {
+	t.Parallel()
+	uses, err := FindFinalizers(loadTestdata(t, "finalizer"))
+	require.NoError(t, err)
+	require.Len(t, uses, 1)
+	assert.Equal(t, "*file", uses[0].ObjectType)
+	assert.Equal(t, 9, uses[0].Line)
+}
+
+func TestFindFunctionsCallingGC(t *testing.T) {
+	t.Parallel()
+	names, err := FindFunctionsCallingGC(loadTestdata(t, "gc"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Free", "T.Reset"}, names)
+}
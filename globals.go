@@ -0,0 +1,59 @@
+package forklift
+
+import (
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// GlobalVar is a package-level variable.
+type GlobalVar struct {
+	// Name is the variable name.
+	Name string
+
+	// TypeString is the variable type.
+	TypeString string
+
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+
+	// Exported is whether the variable is exported.
+	Exported bool
+
+	// LikelyMutable is whether the variable type is a pointer, slice, map, or interface.
+	LikelyMutable bool
+}
+
+// FindGlobalState returns the package-level variables in p sorted by name.
+// It requires types.
+func FindGlobalState(p *packages.Package) ([]GlobalVar, error) {
+	if err := need(p, packages.NeedTypes); err != nil {
+		return nil, err
+	}
+	var vars []GlobalVar
+	scope := p.Types.Scope()
+	for _, name := range scope.Names() {
+		v, ok := scope.Lookup(name).(*types.Var)
+		if !ok {
+			continue
+		}
+		var mutable bool
+		switch v.Type().Underlying().(type) {
+		case *types.Pointer, *types.Slice, *types.Map, *types.Interface:
+			mutable = true
+		}
+		file, line := position(p, v.Pos())
+		vars = append(vars, GlobalVar{
+			Name:          name,
+			TypeString:    types.TypeString(v.Type(), types.RelativeTo(p.Types)),
+			File:          file,
+			Line:          line,
+			Exported:      v.Exported(),
+			LikelyMutable: mutable,
+		})
+	}
+	return vars, nil
+}
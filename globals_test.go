@@ -0,0 +1,24 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindGlobalState(t *testing.T) {
+	t.Parallel()
+	vars, err := FindGlobalState(loadTestdata(t, "globals"))
+	require.NoError(t, err)
+	for i := range vars {
+		vars[i].File = ""
+	}
+	assert.Equal(t, []GlobalVar{
+		{Name: "Count", TypeString: "int", Line: 5, Exported: true},
+		{Name: "Writer", TypeString: "io.Writer", Line: 11, Exported: true, LikelyMutable: true},
+		{Name: "cache", TypeString: "map[string]int", Line: 7, LikelyMutable: true},
+		{Name: "names", TypeString: "[]string", Line: 10, LikelyMutable: true},
+		{Name: "ptr", TypeString: "*int", Line: 12, LikelyMutable: true},
+	}, vars)
+}
@@ -0,0 +1,102 @@
+package forklift
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// hashFiles writes the base names, sizes, and contents of files to w, sorted by base name.
+func hashFiles(w io.Writer, files []string) error {
+	files = append([]string(nil), files...)
+	sort.Slice(files, func(i, j int) bool {
+		return filepath.Base(files[i]) < filepath.Base(files[j])
+	})
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "%s\n%d\n", filepath.Base(file), len(src)); err != nil {
+			return err
+		}
+		if _, err := w.Write(src); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HashPackage returns the hex-encoded SHA-256 hash of the files of p.
+// The hash is of the base names, sizes, and contents of the files, sorted by base name,
+// so it does not depend on the directory of p.
+// It requires files.
+func HashPackage(p *packages.Package) (string, error) {
+	if err := need(p, packages.NeedFiles); err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	if err := hashFiles(h, p.GoFiles); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// loadedMode returns the mode p was loaded with, derived from which fields of p are populated.
+func loadedMode(p *packages.Package) packages.LoadMode {
+	var mode packages.LoadMode
+	set := func(m packages.LoadMode, populated bool) {
+		if populated {
+			mode |= m
+		}
+	}
+	set(packages.NeedName, p.Name != "" || p.PkgPath != "")
+	set(packages.NeedFiles, p.GoFiles != nil || p.OtherFiles != nil || p.IgnoredFiles != nil)
+	set(packages.NeedCompiledGoFiles, p.CompiledGoFiles != nil)
+	set(packages.NeedImports, p.Imports != nil)
+	for _, dep := range p.Imports {
+		// Without dependencies, imports have only IDs.
+		set(packages.NeedDeps, dep.Name != "" || dep.PkgPath != "" || dep.GoFiles != nil || dep.Types != nil)
+	}
+	set(packages.NeedTypes, p.Types != nil)
+	set(packages.NeedSyntax, p.Syntax != nil)
+	set(packages.NeedTypesInfo, p.TypesInfo != nil)
+	set(packages.NeedTypesSizes, p.TypesSizes != nil)
+	set(packages.NeedModule, p.Module != nil)
+	set(packages.NeedEmbedFiles, p.EmbedFiles != nil)
+	set(packages.NeedEmbedPatterns, p.EmbedPatterns != nil)
+	return mode
+}
+
+// ComputeChecksum returns the hex-encoded SHA-256 hash of p and the mode it was loaded with.
+// The hash is of the lines "module <path>", "version <version>", and "mode <mode>",
+// where the path and version are empty if the module is unknown,
+// and the mode is the decimal [golang.org/x/tools/go/packages.LoadMode]
+// derived from which fields of p are populated,
+// followed by the files hashed like [HashPackage].
+// Since the mode is derived, information that was loaded but is empty,
+// like the imports of a package without imports, is not included in the mode.
+// It requires files.
+func ComputeChecksum(p *packages.Package) (string, error) {
+	if err := need(p, packages.NeedFiles); err != nil {
+		return "", err
+	}
+	var path, version string
+	if p.Module != nil {
+		path, version = p.Module.Path, p.Module.Version
+	}
+	h := sha256.New()
+	if _, err := fmt.Fprintf(h, "module %s\nversion %s\nmode %d\n", path, version, loadedMode(p)); err != nil {
+		return "", err
+	}
+	if err := hashFiles(h, p.GoFiles); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
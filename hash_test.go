@@ -0,0 +1,66 @@
+package forklift
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestHashPackage(t *testing.T) {
+	t.Parallel()
+	p := loadTestdata(t, "initorder")
+	h, err := HashPackage(p)
+	require.NoError(t, err)
+	assert.Len(t, h, 64)
+	dir := t.TempDir()
+	var files []string
+	for _, f := range p.GoFiles {
+		src, err := os.ReadFile(f)
+		require.NoError(t, err)
+		copied := filepath.Join(dir, filepath.Base(f))
+		require.NoError(t, os.WriteFile(copied, src, 0o600))
+		files = append([]string{copied}, files...)
+	}
+	copied, err := HashPackage(&packages.Package{GoFiles: files})
+	require.NoError(t, err)
+	assert.Equal(t, h, copied)
+	other, err := HashPackage(loadTestdata(t, "deadcode"))
+	require.NoError(t, err)
+	assert.NotEqual(t, h, other)
+	unloaded, err := Loader{Mode: packages.NeedName}.LoadPackage("./testdata/initorder")
+	require.NoError(t, err)
+	_, err = HashPackage(unloaded)
+	assert.ErrorIs(t, err, ErrNotLoaded)
+	_, err = HashPackage(nil)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestComputeChecksum(t *testing.T) {
+	t.Parallel()
+	p := loadTestdata(t, "initorder")
+	c, err := ComputeChecksum(p)
+	require.NoError(t, err)
+	assert.Len(t, c, 64)
+	h, err := HashPackage(p)
+	require.NoError(t, err)
+	assert.NotEqual(t, h, c)
+	minimal, err := Loader{Mode: packages.NeedName | packages.NeedFiles}.LoadPackage("./testdata/initorder")
+	require.NoError(t, err)
+	assert.Equal(t, packages.NeedName|packages.NeedFiles, loadedMode(minimal))
+	other, err := ComputeChecksum(minimal)
+	require.NoError(t, err)
+	assert.NotEqual(t, c, other)
+	again, err := ComputeChecksum(loadTestdata(t, "initorder"))
+	require.NoError(t, err)
+	assert.Equal(t, c, again)
+	_, err = ComputeChecksum(&packages.Package{Name: "initorder"})
+	assert.ErrorIs(t, err, ErrNotLoaded)
+	p.Module = &packages.Module{Path: "example.com/m", Version: "v1.0.0"}
+	versioned, err := ComputeChecksum(p)
+	require.NoError(t, err)
+	assert.NotEqual(t, c, versioned)
+}
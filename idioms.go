@@ -0,0 +1,174 @@
+package forklift
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// IdiomRule is a Go idiom.
+type IdiomRule string
+
+const (
+	// IdiomErrName is naming error variables err instead of e.
+	IdiomErrName IdiomRule = "err-name"
+
+	// IdiomErrCheck is checking an error variable immediately after the statement that assigns it.
+	IdiomErrCheck IdiomRule = "err-check"
+
+	// IdiomCommaOK is using the comma-ok form of type assertions.
+	IdiomCommaOK IdiomRule = "comma-ok"
+)
+
+// IdiomViolation is a violation of a Go idiom.
+type IdiomViolation struct {
+	// RuleName is the rule name.
+	RuleName string
+
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+
+	// Suggestion is how to follow the idiom.
+	Suggestion string
+}
+
+// FindNonIdiomatic returns the violations of rules in p, sorted by position.
+// Type switches are excluded from [IdiomCommaOK].
+// It requires syntax and types info.
+func FindNonIdiomatic(p *packages.Package, rules []IdiomRule) ([]IdiomViolation, error) {
+	if err := need(p, packages.NeedSyntax|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	enabled := map[IdiomRule]bool{}
+	for _, r := range rules {
+		switch r {
+		case IdiomErrName, IdiomErrCheck, IdiomCommaOK:
+			enabled[r] = true
+		default:
+			return nil, fmt.Errorf("invalid idiom rule: %q", r)
+		}
+	}
+	type violation struct {
+		pos token.Pos
+		IdiomViolation
+	}
+	var violations []violation
+	add := func(r IdiomRule, pos token.Pos, suggestion string) {
+		file, line := position(p, pos)
+		violations = append(violations, violation{pos, IdiomViolation{RuleName: string(r), File: file, Line: line, Suggestion: suggestion}})
+	}
+	for _, f := range p.Syntax {
+		ok := commaOK(f)
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.Ident:
+				if !enabled[IdiomErrName] || n.Name != "e" {
+					break
+				}
+				if v, isVar := p.TypesInfo.Defs[n].(*types.Var); isVar && !v.IsField() && isError(v.Type()) {
+					add(IdiomErrName, n.Pos(), "rename e to err")
+				}
+			case *ast.TypeAssertExpr:
+				if enabled[IdiomCommaOK] && n.Type != nil && !ok[n] {
+					add(IdiomCommaOK, n.Pos(), fmt.Sprintf("use v, ok := %s.(%s)", types.ExprString(n.X), types.ExprString(n.Type)))
+				}
+			case *ast.BlockStmt:
+				if enabled[IdiomErrCheck] {
+					checkErrs(p, n.List, add)
+				}
+			case *ast.CaseClause:
+				if enabled[IdiomErrCheck] {
+					checkErrs(p, n.Body, add)
+				}
+			case *ast.CommClause:
+				if enabled[IdiomErrCheck] {
+					checkErrs(p, n.Body, add)
+				}
+			}
+			return true
+		})
+	}
+	sort.SliceStable(violations, func(i, j int) bool { return violations[i].pos < violations[j].pos })
+	result := make([]IdiomViolation, len(violations))
+	for i, v := range violations {
+		result[i] = v.IdiomViolation
+	}
+	return result, nil
+}
+
+// checkErrs calls add for each "if err != nil" statement in stmts
+// that follows a statement in stmts that assigns err, but not immediately.
+func checkErrs(p *packages.Package, stmts []ast.Stmt, add func(IdiomRule, token.Pos, string)) {
+	for i, s := range stmts {
+		s, ok := s.(*ast.IfStmt)
+		if !ok || s.Init != nil {
+			continue
+		}
+		v := nonNilError(p, s.Cond)
+		if v == nil || i == 0 || assigns(p, stmts[i-1], v) {
+			continue
+		}
+		for _, prev := range stmts[:i-1] {
+			if assigns(p, prev, v) {
+				add(IdiomErrCheck, s.Pos(), fmt.Sprintf("check %s immediately after assigning it", v.Name()))
+				break
+			}
+		}
+	}
+}
+
+// nonNilError returns the error variable compared in cond, like "err != nil", or nil.
+func nonNilError(p *packages.Package, cond ast.Expr) *types.Var {
+	b, ok := ast.Unparen(cond).(*ast.BinaryExpr)
+	if !ok || b.Op != token.NEQ {
+		return nil
+	}
+	x, y := ast.Unparen(b.X), ast.Unparen(b.Y)
+	if id, ok := x.(*ast.Ident); ok && id.Name == "nil" {
+		x, y = y, x
+	}
+	if id, ok := y.(*ast.Ident); !ok || id.Name != "nil" {
+		return nil
+	}
+	id, ok := x.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	v, ok := p.TypesInfo.Uses[id].(*types.Var)
+	if !ok || !isError(v.Type()) {
+		return nil
+	}
+	return v
+}
+
+// assigns returns whether s assigns v.
+func assigns(p *packages.Package, s ast.Stmt, v *types.Var) bool {
+	switch s := s.(type) {
+	case *ast.AssignStmt:
+		for _, lhs := range s.Lhs {
+			if id, ok := ast.Unparen(lhs).(*ast.Ident); ok && p.TypesInfo.ObjectOf(id) == v {
+				return true
+			}
+		}
+	case *ast.DeclStmt:
+		if d, ok := s.Decl.(*ast.GenDecl); ok {
+			for _, spec := range d.Specs {
+				if spec, ok := spec.(*ast.ValueSpec); ok {
+					for _, name := range spec.Names {
+						if p.TypesInfo.Defs[name] == v {
+							return true
+						}
+					}
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,32 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindNonIdiomatic(t *testing.T) {
+	t.Parallel()
+	p := loadTestdata(t, "idioms")
+	violations, err := FindNonIdiomatic(p, []IdiomRule{IdiomErrName, IdiomErrCheck, IdiomCommaOK})
+	require.NoError(t, err)
+	for i := range violations {
+		violations[i].File = ""
+	}
+	assert.Equal(t, []IdiomViolation{
+		{RuleName: "err-name", Line: 9, Suggestion: "rename e to err"},
+		{RuleName: "err-check", Line: 22, Suggestion: "check err immediately after assigning it"},
+		{RuleName: "err-check", Line: 30, Suggestion: "check err2 immediately after assigning it"},
+		{RuleName: "comma-ok", Line: 45, Suggestion: "use v, ok := x.(string)"},
+	}, violations)
+	violations, err = FindNonIdiomatic(p, []IdiomRule{IdiomCommaOK})
+	require.NoError(t, err)
+	assert.Len(t, violations, 1)
+	violations, err = FindNonIdiomatic(p, nil)
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+	_, err = FindNonIdiomatic(p, []IdiomRule{"bogus"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,84 @@
+package forklift
+
+import (
+	"go/ast"
+	"go/types"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// InitInfo is an init function declaration.
+type InitInfo struct {
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+}
+
+// FindInitFunctions returns the init function declarations in p.
+// It requires syntax.
+func FindInitFunctions(p *packages.Package) ([]InitInfo, error) {
+	if err := need(p, packages.NeedSyntax); err != nil {
+		return nil, err
+	}
+	var inits []InitInfo
+	for _, f := range p.Syntax {
+		for _, d := range f.Decls {
+			if d, ok := d.(*ast.FuncDecl); ok && d.Recv == nil && d.Name.Name == "init" {
+				file, line := position(p, d.Pos())
+				inits = append(inits, InitInfo{File: file, Line: line})
+			}
+		}
+	}
+	return inits, nil
+}
+
+// InitOrderEntry is a package-level variable initialization.
+type InitOrderEntry struct {
+	// VarName is the variable name.
+	VarName string
+
+	// InitializerExpr is the initialization expression.
+	InitializerExpr string
+
+	// DependsOn is the sorted names of the package-level variables and functions
+	// referenced by the initialization expression.
+	DependsOn []string
+}
+
+// AnalyzeInitOrder returns the package-level variable initializations in p
+// in the order they are executed.
+// It requires types and types info.
+func AnalyzeInitOrder(p *packages.Package) ([]InitOrderEntry, error) {
+	if err := need(p, packages.NeedTypes|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	var entries []InitOrderEntry
+	for _, init := range p.TypesInfo.InitOrder {
+		seen := map[string]bool{}
+		var deps []string
+		ast.Inspect(init.Rhs, func(n ast.Node) bool {
+			id, ok := n.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			obj := p.TypesInfo.Uses[id]
+			if obj == nil || obj.Parent() != p.Types.Scope() || seen[obj.Name()] {
+				return true
+			}
+			switch obj.(type) {
+			case *types.Var, *types.Func:
+				seen[obj.Name()] = true
+				deps = append(deps, obj.Name())
+			}
+			return true
+		})
+		sort.Strings(deps)
+		for _, v := range init.Lhs {
+			entries = append(entries, InitOrderEntry{VarName: v.Name(), InitializerExpr: types.ExprString(init.Rhs), DependsOn: deps})
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,31 @@
+package forklift
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindInitFunctions(t *testing.T) {
+	t.Parallel()
+	inits, err := FindInitFunctions(loadTestdata(t, "initorder"))
+	require.NoError(t, err)
+	require.Len(t, inits, 2)
+	assert.Equal(t, "a.go", filepath.Base(inits[0].File))
+	assert.Equal(t, 13, inits[0].Line)
+	assert.Equal(t, "b.go", filepath.Base(inits[1].File))
+	assert.Equal(t, 3, inits[1].Line)
+}
+
+func TestAnalyzeInitOrder(t *testing.T) {
+	t.Parallel()
+	entries, err := AnalyzeInitOrder(loadTestdata(t, "initorder"))
+	require.NoError(t, err)
+	assert.Equal(t, []InitOrderEntry{
+		{VarName: "c", InitializerExpr: "1"},
+		{VarName: "b", InitializerExpr: "f()", DependsOn: []string{"f"}},
+		{VarName: "a", InitializerExpr: "b + c", DependsOn: []string{"b", "c"}},
+	}, entries)
+}
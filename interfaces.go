@@ -0,0 +1,424 @@
+package forklift
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// LargeIface is an interface with many methods.
+type LargeIface struct {
+	// Name is the interface name.
+	Name string
+
+	// NumMethods is the number of methods, including embedded ones.
+	NumMethods int
+
+	// Methods is the sorted method names.
+	Methods []string
+}
+
+// namedInterfaces returns the interface types declared at package level in p sorted by name.
+func namedInterfaces(p *packages.Package) []*types.TypeName {
+	var ts []*types.TypeName
+	scope := p.Types.Scope()
+	for _, name := range scope.Names() {
+		if t, ok := scope.Lookup(name).(*types.TypeName); ok && !t.IsAlias() && types.IsInterface(t.Type()) {
+			ts = append(ts, t)
+		}
+	}
+	return ts
+}
+
+// FindLargeInterfaces returns the interfaces in p with more than maxMethods methods,
+// including embedded ones, sorted by name.
+// It requires types.
+func FindLargeInterfaces(p *packages.Package, maxMethods int) ([]LargeIface, error) {
+	if err := need(p, packages.NeedTypes); err != nil {
+		return nil, err
+	}
+	var large []LargeIface
+	for _, t := range namedInterfaces(p) {
+		i := t.Type().Underlying().(*types.Interface)
+		if i.NumMethods() <= maxMethods {
+			continue
+		}
+		methods := make([]string, i.NumMethods())
+		for j := range methods {
+			methods[j] = i.Method(j).Name()
+		}
+		sort.Strings(methods)
+		large = append(large, LargeIface{Name: t.Name(), NumMethods: i.NumMethods(), Methods: methods})
+	}
+	return large, nil
+}
+
+// SingleMethodIface is an interface with one method.
+type SingleMethodIface struct {
+	// Name is the interface name.
+	Name string
+
+	// MethodName is the method name.
+	MethodName string
+
+	// Signature is the method signature, like "func(p []byte) (n int, err error)".
+	Signature string
+}
+
+// FindSingleMethodInterfaces returns the interfaces in p with one method,
+// including embedded ones, sorted by name.
+// It requires types.
+func FindSingleMethodInterfaces(p *packages.Package) ([]SingleMethodIface, error) {
+	if err := need(p, packages.NeedTypes); err != nil {
+		return nil, err
+	}
+	var single []SingleMethodIface
+	for _, t := range namedInterfaces(p) {
+		i := t.Type().Underlying().(*types.Interface)
+		if i.NumMethods() != 1 {
+			continue
+		}
+		m := i.Method(0)
+		single = append(single, SingleMethodIface{
+			Name:       t.Name(),
+			MethodName: m.Name(),
+			Signature:  types.TypeString(m.Type(), types.RelativeTo(p.Types)),
+		})
+	}
+	return single, nil
+}
+
+// ErrTypeNotFound means the type was not found.
+var ErrTypeNotFound = fmt.Errorf("type not found")
+
+// lookupType returns the package-level type named name in p.
+// It returns [ErrTypeNotFound] if the type is not found.
+func lookupType(p *packages.Package, name string) (*types.TypeName, error) {
+	t, ok := p.Types.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil, ErrTypeNotFound
+	}
+	return t, nil
+}
+
+// lookupInterface returns the package-level interface type named name in p.
+// It returns [ErrTypeNotFound] if the type is not found.
+func lookupInterface(p *packages.Package, name string) (*types.Interface, error) {
+	t, err := lookupType(p, name)
+	if err != nil {
+		return nil, err
+	}
+	i, ok := t.Type().Underlying().(*types.Interface)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an interface", name)
+	}
+	return i, nil
+}
+
+// implementations returns the names of the non-interface named types in p that implement i,
+// prefixed with "*" if only their pointer types do, sorted by name.
+func implementations(p *packages.Package, i *types.Interface) []string {
+	var names []string
+	scope := p.Types.Scope()
+	for _, name := range scope.Names() {
+		t, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || t.IsAlias() || types.IsInterface(t.Type()) {
+			continue
+		}
+		if types.Implements(t.Type(), i) {
+			names = append(names, name)
+		} else if types.Implements(types.NewPointer(t.Type()), i) {
+			names = append(names, "*"+name)
+		}
+	}
+	return names
+}
+
+// FindConcreteTypesForInterface returns the names of the non-interface named types in p
+// that implement the interface in p named ifaceName, sorted by name.
+// Names are prefixed with "*" if only their pointer types implement the interface.
+// It returns [ErrTypeNotFound] if the interface is not found.
+// It requires types.
+func FindConcreteTypesForInterface(p *packages.Package, ifaceName string) ([]string, error) {
+	if err := need(p, packages.NeedTypes); err != nil {
+		return nil, err
+	}
+	i, err := lookupInterface(p, ifaceName)
+	if err != nil {
+		return nil, err
+	}
+	return implementations(p, i), nil
+}
+
+type importKey struct {
+	dir, path string
+}
+
+var (
+	importsMutex sync.Mutex
+	imports      = map[importKey]*types.Package{}
+)
+
+// importedPackage returns the package for path as seen by p.
+// If p imports the package directly or indirectly, that package is used,
+// so its objects are identical to the ones used by p.
+// Otherwise, the package types are loaded from the directory of p and cached,
+// as is a package that is not found.
+// It returns [ErrNotFound] if the package is not found.
+func importedPackage(p *packages.Package, path string) (*types.Package, error) {
+	seen := map[*packages.Package]bool{}
+	var find func(*packages.Package) *packages.Package
+	find = func(p *packages.Package) *packages.Package {
+		for _, dep := range p.Imports {
+			if seen[dep] {
+				continue
+			}
+			seen[dep] = true
+			if dep.PkgPath == path {
+				return dep
+			}
+			if found := find(dep); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+	if dep := find(p); dep != nil && dep.Types != nil {
+		return dep.Types, nil
+	}
+	var key importKey
+	if len(p.GoFiles) > 0 {
+		key.dir = filepath.Dir(p.GoFiles[0])
+	}
+	key.path = path
+	importsMutex.Lock()
+	defer importsMutex.Unlock()
+	if t, ok := imports[key]; ok {
+		if t == nil {
+			return nil, ErrNotFound
+		}
+		return t, nil
+	}
+	dep, err := Loader{Dir: key.dir, Mode: packages.NeedName | packages.NeedTypes}.LoadPackage(path)
+	if errors.Is(err, ErrNotFound) {
+		imports[key] = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	imports[key] = dep.Types
+	return dep.Types, nil
+}
+
+// importedInterface returns the interface type named name in the package for path as seen by p.
+func importedInterface(p *packages.Package, path, name string) (*types.Interface, error) {
+	t, err := importedPackage(p, path)
+	if err != nil {
+		return nil, err
+	}
+	obj, ok := t.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil, ErrTypeNotFound
+	}
+	i, ok := obj.Type().Underlying().(*types.Interface)
+	if !ok {
+		return nil, fmt.Errorf("%s.%s is not an interface", path, name)
+	}
+	return i, nil
+}
+
+// FindProtobufMessages returns the names of the non-interface named types in p
+// that implement google.golang.org/protobuf/proto.Message, sorted by name.
+// Names are prefixed with "*" if only their pointer types implement the interface.
+// If the proto package is not available to p, no types implement it.
+// It requires types and imports.
+func FindProtobufMessages(p *packages.Package) ([]string, error) {
+	if err := need(p, packages.NeedTypes); err != nil {
+		return nil, err
+	}
+	i, err := importedInterface(p, "google.golang.org/protobuf/proto", "Message")
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return implementations(p, i), nil
+}
+
+// FindFlagValues returns the names of the non-interface named types in p
+// that implement flag.Value, sorted by name.
+// Names are prefixed with "*" if only their pointer types implement the interface.
+// It requires types.
+func FindFlagValues(p *packages.Package) ([]string, error) {
+	if err := need(p, packages.NeedTypes); err != nil {
+		return nil, err
+	}
+	i, err := importedInterface(p, "flag", "Value")
+	if err != nil {
+		return nil, err
+	}
+	return implementations(p, i), nil
+}
+
+// SQLTypeInfo is a type that implements database/sql.Scanner or database/sql/driver.Valuer.
+type SQLTypeInfo struct {
+	// Name is the type name.
+	Name string
+
+	// ImplementsScanner is whether the type or its pointer type implements database/sql.Scanner.
+	ImplementsScanner bool
+
+	// ImplementsValuer is whether the type or its pointer type implements database/sql/driver.Valuer.
+	ImplementsValuer bool
+}
+
+// FindSQLScanners returns the non-interface named types in p that implement
+// database/sql.Scanner or database/sql/driver.Valuer, sorted by name.
+// It requires types.
+func FindSQLScanners(p *packages.Package) ([]SQLTypeInfo, error) {
+	if err := need(p, packages.NeedTypes); err != nil {
+		return nil, err
+	}
+	scanner, err := importedInterface(p, "database/sql", "Scanner")
+	if err != nil {
+		return nil, err
+	}
+	valuer, err := importedInterface(p, "database/sql/driver", "Valuer")
+	if err != nil {
+		return nil, err
+	}
+	implements := func(t types.Type, i *types.Interface) bool {
+		return types.Implements(t, i) || types.Implements(types.NewPointer(t), i)
+	}
+	var infos []SQLTypeInfo
+	scope := p.Types.Scope()
+	for _, name := range scope.Names() {
+		t, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || t.IsAlias() || types.IsInterface(t.Type()) {
+			continue
+		}
+		info := SQLTypeInfo{Name: name, ImplementsScanner: implements(t.Type(), scanner), ImplementsValuer: implements(t.Type(), valuer)}
+		if info.ImplementsScanner || info.ImplementsValuer {
+			infos = append(infos, info)
+		}
+	}
+	return infos, nil
+}
+
+// FindInterfaceEmbedCycles returns the cycles of embedded interfaces in the package-level interface types in p.
+// Each cycle is a path of interface names that starts and ends with the same name.
+// Interfaces embedded in anonymous interfaces, unions, and instantiations are embedded in the enclosing type.
+// Types are not needed, since the type checker rejects such cycles.
+// It requires syntax.
+func FindInterfaceEmbedCycles(p *packages.Package) ([][]string, error) {
+	if err := need(p, packages.NeedSyntax); err != nil {
+		return nil, err
+	}
+	embeds := map[string][]string{}
+	for _, f := range p.Syntax {
+		for _, d := range f.Decls {
+			d, ok := d.(*ast.GenDecl)
+			if !ok || d.Tok != token.TYPE {
+				continue
+			}
+			for _, s := range d.Specs {
+				s := s.(*ast.TypeSpec)
+				i, ok := s.Type.(*ast.InterfaceType)
+				if !ok {
+					continue
+				}
+				embeds[s.Name.Name] = embeddedNames(i)
+			}
+		}
+	}
+	for name, es := range embeds {
+		var local []string
+		for _, e := range es {
+			if _, ok := embeds[e]; ok {
+				local = append(local, e)
+			}
+		}
+		sort.Strings(local)
+		embeds[name] = local
+	}
+	names := make([]string, 0, len(embeds))
+	for name := range embeds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	var cycles [][]string
+	var path []string
+	state := map[string]int{}
+	var visit func(string)
+	visit = func(name string) {
+		state[name] = visiting
+		path = append(path, name)
+		for _, e := range embeds[name] {
+			switch state[e] {
+			case unvisited:
+				visit(e)
+			case visiting:
+				var cycle []string
+				for i := len(path) - 1; i >= 0; i-- {
+					if path[i] == e {
+						cycle = append(cycle, path[i:]...)
+						break
+					}
+				}
+				cycles = append(cycles, append(cycle, e))
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+	}
+	for _, name := range names {
+		if state[name] == unvisited {
+			visit(name)
+		}
+	}
+	return cycles, nil
+}
+
+// embeddedNames returns the names of the types embedded in i.
+func embeddedNames(i *ast.InterfaceType) []string {
+	var names []string
+	var walk func(ast.Expr)
+	walk = func(e ast.Expr) {
+		switch e := e.(type) {
+		case *ast.Ident:
+			names = append(names, e.Name)
+		case *ast.ParenExpr:
+			walk(e.X)
+		case *ast.UnaryExpr:
+			walk(e.X)
+		case *ast.BinaryExpr:
+			walk(e.X)
+			walk(e.Y)
+		case *ast.IndexExpr:
+			walk(e.X)
+		case *ast.IndexListExpr:
+			walk(e.X)
+		case *ast.InterfaceType:
+			names = append(names, embeddedNames(e)...)
+		}
+	}
+	for _, field := range i.Methods.List {
+		if len(field.Names) == 0 {
+			walk(field.Type)
+		}
+	}
+	return names
+}
@@ -0,0 +1,93 @@
+package forklift
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestFindLargeInterfaces(t *testing.T) {
+	t.Parallel()
+	large, err := FindLargeInterfaces(loadTestdata(t, "interfaces"), 2)
+	require.NoError(t, err)
+	assert.Equal(t, []LargeIface{{Name: "big", NumMethods: 4, Methods: []string{"Close", "Read", "Write", "flush"}}}, large)
+}
+
+func TestFindSingleMethodInterfaces(t *testing.T) {
+	t.Parallel()
+	single, err := FindSingleMethodInterfaces(loadTestdata(t, "interfaces"))
+	require.NoError(t, err)
+	assert.Equal(t, []SingleMethodIface{{Name: "Reader", MethodName: "Read", Signature: "func(p []byte) (n int, err error)"}}, single)
+}
+
+func TestFindConcreteTypesForInterface(t *testing.T) {
+	t.Parallel()
+	p := loadTestdata(t, "interfaces")
+	names, err := FindConcreteTypesForInterface(p, "Reader")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"*file", "value"}, names)
+	names, err = FindConcreteTypesForInterface(p, "ReadCloser")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"*file"}, names)
+	_, err = FindConcreteTypesForInterface(p, "Missing")
+	assert.Equal(t, ErrTypeNotFound, err)
+	_, err = FindConcreteTypesForInterface(p, "file")
+	assert.Error(t, err)
+}
+
+func TestFindProtobufMessages(t *testing.T) {
+	t.Parallel()
+	names, err := FindProtobufMessages(loadTestdata(t, "interfaces"))
+	require.NoError(t, err)
+	assert.Empty(t, names)
+}
+
+func TestFindFlagValues(t *testing.T) {
+	t.Parallel()
+	names, err := FindFlagValues(loadTestdata(t, "implementers"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"*level", "name"}, names)
+}
+
+func TestFindSQLScanners(t *testing.T) {
+	t.Parallel()
+	infos, err := FindSQLScanners(loadTestdata(t, "implementers"))
+	require.NoError(t, err)
+	assert.Equal(t, []SQLTypeInfo{
+		{Name: "both", ImplementsScanner: true, ImplementsValuer: true},
+		{Name: "scanned", ImplementsScanner: true},
+	}, infos)
+}
+
+func TestFindInterfaceEmbedCycles(t *testing.T) {
+	t.Parallel()
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "cycles.go", `package cycles
+
+type A interface{ B }
+
+type B[T any] interface{ interface{ C[T] } | int }
+
+type C[T any] interface{ ~int | A }
+
+type D interface{ D }
+
+type E interface{ A; F }
+
+type F interface{ Read() }
+
+type G struct{ G }
+`, 0)
+	require.NoError(t, err)
+	cycles, err := FindInterfaceEmbedCycles(&packages.Package{Fset: fset, Syntax: []*ast.File{f}})
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"A", "B", "C", "A"}, {"D", "D"}}, cycles)
+	cycles, err = FindInterfaceEmbedCycles(loadTestdata(t, "interfaces"))
+	require.NoError(t, err)
+	assert.Empty(t, cycles)
+}
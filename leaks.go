@@ -0,0 +1,113 @@
+package forklift
+
+import (
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// TypeLeak is an unexported type in an exported API.
+type TypeLeak struct {
+	// SymbolName is the exported function, method, or field name.
+	// Methods and fields are qualified by their type name.
+	SymbolName string
+
+	// LeakedType is the unexported type name.
+	LeakedType string
+
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+}
+
+// namedTypes calls visit for each named type in t, not including the underlying types of named types.
+func namedTypes(t types.Type, visit func(*types.Named)) {
+	switch t := t.(type) {
+	case *types.Named:
+		visit(t)
+		if args := t.TypeArgs(); args != nil {
+			for i := 0; i < args.Len(); i++ {
+				namedTypes(args.At(i), visit)
+			}
+		}
+	case *types.Pointer:
+		namedTypes(t.Elem(), visit)
+	case *types.Slice:
+		namedTypes(t.Elem(), visit)
+	case *types.Array:
+		namedTypes(t.Elem(), visit)
+	case *types.Chan:
+		namedTypes(t.Elem(), visit)
+	case *types.Map:
+		namedTypes(t.Key(), visit)
+		namedTypes(t.Elem(), visit)
+	case *types.Tuple:
+		for i := 0; i < t.Len(); i++ {
+			namedTypes(t.At(i).Type(), visit)
+		}
+	case *types.Signature:
+		namedTypes(t.Params(), visit)
+		namedTypes(t.Results(), visit)
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			namedTypes(t.Field(i).Type(), visit)
+		}
+	case *types.Interface:
+		for i := 0; i < t.NumMethods(); i++ {
+			namedTypes(t.Method(i).Type(), visit)
+		}
+	}
+}
+
+// FindUnexportedTypeLeaks returns the unexported types of p in the exported functions, methods,
+// and struct fields of p.
+// It requires types.
+func FindUnexportedTypeLeaks(p *packages.Package) ([]TypeLeak, error) {
+	if err := need(p, packages.NeedTypes); err != nil {
+		return nil, err
+	}
+	var leaks []TypeLeak
+	check := func(symbol string, obj types.Object) {
+		seen := map[*types.TypeName]bool{}
+		namedTypes(obj.Type(), func(n *types.Named) {
+			leaked := n.Obj()
+			if leaked.Pkg() != p.Types || leaked.Exported() || seen[leaked] {
+				return
+			}
+			seen[leaked] = true
+			file, line := position(p, obj.Pos())
+			leaks = append(leaks, TypeLeak{SymbolName: symbol, LeakedType: leaked.Name(), File: file, Line: line})
+		})
+	}
+	scope := p.Types.Scope()
+	for _, name := range scope.Names() {
+		obj := scope.Lookup(name)
+		if !obj.Exported() {
+			continue
+		}
+		switch obj := obj.(type) {
+		case *types.Func:
+			check(name, obj)
+		case *types.TypeName:
+			named, ok := obj.Type().(*types.Named)
+			if !ok || obj.IsAlias() {
+				continue
+			}
+			if s, ok := named.Underlying().(*types.Struct); ok {
+				for i := 0; i < s.NumFields(); i++ {
+					if f := s.Field(i); f.Exported() {
+						check(name+"."+f.Name(), f)
+					}
+				}
+			}
+			for i := 0; i < named.NumMethods(); i++ {
+				if m := named.Method(i); m.Exported() {
+					check(name+"."+m.Name(), m)
+				}
+			}
+		}
+	}
+	return leaks, nil
+}
@@ -0,0 +1,23 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindUnexportedTypeLeaks(t *testing.T) {
+	t.Parallel()
+	leaks, err := FindUnexportedTypeLeaks(loadTestdata(t, "leaks"))
+	require.NoError(t, err)
+	for i := range leaks {
+		leaks[i].File = ""
+	}
+	assert.Equal(t, []TypeLeak{
+		{SymbolName: "New", LeakedType: "hidden", Line: 11},
+		{SymbolName: "Public.Field", LeakedType: "hidden", Line: 6},
+		{SymbolName: "Public.Nested", LeakedType: "hidden", Line: 8},
+		{SymbolName: "Public.Method", LeakedType: "hidden", Line: 15},
+	}, leaks)
+}
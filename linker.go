@@ -0,0 +1,72 @@
+package forklift
+
+import (
+	"go/ast"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// LinkerDirective is a link-time directive comment.
+type LinkerDirective struct {
+	// Kind is the directive name, like "linkname", "cgo_import_dynamic", "export", or "nosplit".
+	Kind string
+
+	// SymbolName is the local symbol name, or the name of the function declaration for nosplit.
+	SymbolName string
+
+	// Target is the remaining directive arguments, like the target symbol name for linkname, or empty if none.
+	Target string
+
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+}
+
+// ExtractLinkerFlags returns the //go:linkname, //go:cgo_import_dynamic, //export, and //go:nosplit comments in p.
+// It requires syntax.
+func ExtractLinkerFlags(p *packages.Package) ([]LinkerDirective, error) {
+	if err := need(p, packages.NeedSyntax); err != nil {
+		return nil, err
+	}
+	var directives []LinkerDirective
+	for _, f := range p.Syntax {
+		funcs := map[*ast.CommentGroup]string{}
+		for _, d := range f.Decls {
+			if d, ok := d.(*ast.FuncDecl); ok && d.Doc != nil {
+				funcs[d.Doc] = declName(d)
+			}
+		}
+		for _, g := range f.Comments {
+			for _, c := range g.List {
+				var kind string
+				var args []string
+				if text, ok := strings.CutPrefix(c.Text, "//export "); ok {
+					kind, args = "export", strings.Fields(text)
+				} else if text, ok := strings.CutPrefix(c.Text, "//go:"); ok {
+					fields := strings.Fields(text)
+					if len(fields) == 0 {
+						continue
+					}
+					kind, args = fields[0], fields[1:]
+				}
+				d := LinkerDirective{Kind: kind}
+				switch kind {
+				case "linkname", "cgo_import_dynamic", "export":
+					if len(args) > 0 {
+						d.SymbolName, d.Target = args[0], strings.Join(args[1:], " ")
+					}
+				case "nosplit":
+					d.SymbolName = funcs[g]
+				default:
+					continue
+				}
+				d.File, d.Line = position(p, c.Pos())
+				directives = append(directives, d)
+			}
+		}
+	}
+	return directives, nil
+}
@@ -0,0 +1,24 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractLinkerFlags(t *testing.T) {
+	t.Parallel()
+	directives, err := ExtractLinkerFlags(loadTestdata(t, "linker"))
+	require.NoError(t, err)
+	for i := range directives {
+		directives[i].File = ""
+	}
+	assert.Equal(t, []LinkerDirective{
+		{Kind: "linkname", SymbolName: "nanotime", Target: "runtime.nanotime", Line: 5},
+		{Kind: "linkname", SymbolName: "exported", Line: 8},
+		{Kind: "export", SymbolName: "Add", Line: 13},
+		{Kind: "nosplit", SymbolName: "fast", Line: 16},
+		{Kind: "cgo_import_dynamic", SymbolName: "libc_getpid", Target: `getpid "libc.so.6"`, Line: 19},
+	}, directives)
+}
@@ -0,0 +1,118 @@
+package forklift
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// MagicNumber is a numeric literal outside a constant declaration.
+type MagicNumber struct {
+	// Value is the literal, including any sign, like "-1" or "0x10".
+	Value string
+
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+}
+
+// FindMagicNumbers returns the numeric literals in p outside constant declarations,
+// excluding integer literals with values in excludeValues.
+// It requires syntax.
+func FindMagicNumbers(p *packages.Package, excludeValues []int64) ([]MagicNumber, error) {
+	if err := need(p, packages.NeedSyntax); err != nil {
+		return nil, err
+	}
+	exclude := map[int64]bool{}
+	for _, v := range excludeValues {
+		exclude[v] = true
+	}
+	var numbers []MagicNumber
+	add := func(n ast.Node, sign string, lit *ast.BasicLit) {
+		if lit.Kind == token.INT {
+			if v, err := strconv.ParseInt(sign+lit.Value, 0, 64); err == nil && exclude[v] {
+				return
+			}
+		}
+		file, line := position(p, n.Pos())
+		numbers = append(numbers, MagicNumber{Value: sign + lit.Value, File: file, Line: line})
+	}
+	numeric := func(e ast.Expr) *ast.BasicLit {
+		lit, ok := e.(*ast.BasicLit)
+		if !ok || lit.Kind != token.INT && lit.Kind != token.FLOAT && lit.Kind != token.IMAG {
+			return nil
+		}
+		return lit
+	}
+	for _, f := range p.Syntax {
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.GenDecl:
+				return n.Tok != token.CONST
+			case *ast.UnaryExpr:
+				if lit := numeric(n.X); lit != nil && (n.Op == token.SUB || n.Op == token.ADD) {
+					add(n, n.Op.String(), lit)
+					return false
+				}
+			case *ast.BasicLit:
+				if lit := numeric(n); lit != nil {
+					add(n, "", lit)
+				}
+			}
+			return true
+		})
+	}
+	return numbers, nil
+}
+
+// StringLiteral is a string literal outside a constant declaration.
+type StringLiteral struct {
+	// Value is the string value.
+	Value string
+
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+}
+
+// FindHardcodedStrings returns the string literals in p with at least minLength characters
+// outside constant declarations, import declarations, and struct tags.
+// It requires syntax.
+func FindHardcodedStrings(p *packages.Package, minLength int) ([]StringLiteral, error) {
+	if err := need(p, packages.NeedSyntax); err != nil {
+		return nil, err
+	}
+	var literals []StringLiteral
+	for _, f := range p.Syntax {
+		tags := map[*ast.BasicLit]bool{}
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.GenDecl:
+				return n.Tok != token.CONST && n.Tok != token.IMPORT
+			case *ast.Field:
+				if n.Tag != nil {
+					tags[n.Tag] = true
+				}
+			case *ast.BasicLit:
+				if n.Kind != token.STRING || tags[n] {
+					return true
+				}
+				s, err := strconv.Unquote(n.Value)
+				if err != nil || utf8.RuneCountInString(s) < minLength {
+					return true
+				}
+				file, line := position(p, n.Pos())
+				literals = append(literals, StringLiteral{Value: s, File: file, Line: line})
+			}
+			return true
+		})
+	}
+	return literals, nil
+}
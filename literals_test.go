@@ -0,0 +1,51 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindMagicNumbers(t *testing.T) {
+	t.Parallel()
+	p := loadTestdata(t, "literals")
+	numbers, err := FindMagicNumbers(p, nil)
+	require.NoError(t, err)
+	for i := range numbers {
+		numbers[i].File = ""
+	}
+	assert.Equal(t, []MagicNumber{
+		{Value: "0", Line: 9},
+		{Value: "3", Line: 10},
+		{Value: "1", Line: 10},
+		{Value: "-1", Line: 12},
+		{Value: "2.5", Line: 13},
+		{Value: "0x10", Line: 15},
+	}, numbers)
+	numbers, err = FindMagicNumbers(p, []int64{-1, 0, 1, 16})
+	require.NoError(t, err)
+	var values []string
+	for _, n := range numbers {
+		values = append(values, n.Value)
+	}
+	assert.Equal(t, []string{"3", "2.5"}, values)
+}
+
+func TestFindHardcodedStrings(t *testing.T) {
+	t.Parallel()
+	p := loadTestdata(t, "literals")
+	literals, err := FindHardcodedStrings(p, 5)
+	require.NoError(t, err)
+	for i := range literals {
+		literals[i].File = ""
+	}
+	assert.Equal(t, []StringLiteral{
+		{Value: "password123", Line: 25},
+		{Value: "raw string", Line: 25},
+		{Value: "héllo", Line: 25},
+	}, literals)
+	literals, err = FindHardcodedStrings(p, 6)
+	require.NoError(t, err)
+	assert.Len(t, literals, 2)
+}
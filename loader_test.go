@@ -4,8 +4,17 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
 )
 
+func loadTestdata(t *testing.T, name string) *packages.Package {
+	t.Helper()
+	p, err := LoadPackage("./testdata/" + name)
+	require.NoError(t, err)
+	return p
+}
+
 func TestLoadPackage(t *testing.T) {
 	t.Parallel()
 	for _, test := range []string{
@@ -0,0 +1,167 @@
+package forklift
+
+import (
+	"go/ast"
+	"go/scanner"
+	"go/token"
+	"os"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// LOCResult is the number of lines in a package.
+type LOCResult struct {
+	// Code is the number of lines with code in non-test files.
+	Code int
+
+	// Comment is the number of lines with only comments in non-test files.
+	Comment int
+
+	// Blank is the number of blank lines in non-test files.
+	Blank int
+
+	// Total is the number of lines in non-test files.
+	Total int
+
+	// TestCode is the number of lines with code in test files.
+	TestCode int
+
+	// TestComment is the number of lines with only comments in test files.
+	TestComment int
+
+	// TestBlank is the number of blank lines in test files.
+	TestBlank int
+}
+
+// countLines returns the number of code, comment, and blank lines in src.
+func countLines(filename string, src []byte) (code, comment, blank int) {
+	fset := token.NewFileSet()
+	file := fset.AddFile(filename, -1, len(src))
+	lines := strings.Count(string(src), "\n")
+	if len(src) > 0 && src[len(src)-1] != '\n' {
+		lines++
+	}
+	kinds := make([]int, lines+1)
+	mark := func(pos token.Pos, lit string, kind int) {
+		start := file.Line(pos)
+		end := start + strings.Count(lit, "\n")
+		for line := start; line <= end && line <= lines; line++ {
+			if kind > kinds[line] {
+				kinds[line] = kind
+			}
+		}
+	}
+	var s scanner.Scanner
+	s.Init(file, src, nil, scanner.ScanComments)
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		switch {
+		case tok == token.COMMENT:
+			mark(pos, lit, 1)
+		case tok == token.SEMICOLON && lit == "\n":
+		default:
+			if lit == "" {
+				lit = tok.String()
+			}
+			mark(pos, lit, 2)
+		}
+	}
+	return tally(kinds[1:])
+}
+
+// syntaxLines returns the number of code, comment, and blank lines in f, which is in fset.
+func syntaxLines(fset *token.FileSet, f *ast.File) (code, comment, blank int) {
+	file := fset.File(f.Pos())
+	lines := file.LineCount()
+	kinds := make([]int, lines+1)
+	mark := func(start, end token.Pos, kind int) {
+		if !start.IsValid() || !end.IsValid() {
+			return
+		}
+		for line := file.Line(start); line <= file.Line(end) && line <= lines; line++ {
+			if kind > kinds[line] {
+				kinds[line] = kind
+			}
+		}
+	}
+	for _, g := range f.Comments {
+		for _, c := range g.List {
+			mark(c.Pos(), c.End()-1, 1)
+		}
+	}
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case nil, *ast.CommentGroup, *ast.Comment:
+			return false
+		case *ast.BasicLit:
+			mark(n.Pos(), n.End()-1, 2)
+		default:
+			mark(n.Pos(), n.Pos(), 2)
+			mark(n.End()-1, n.End()-1, 2)
+		}
+		return true
+	})
+	return tally(kinds[1:])
+}
+
+// tally returns the number of code, comment, and blank lines in kinds,
+// which are 2 for code, 1 for comments, and 0 for blanks.
+func tally(kinds []int) (code, comment, blank int) {
+	for _, kind := range kinds {
+		switch kind {
+		case 0:
+			blank++
+		case 1:
+			comment++
+		case 2:
+			code++
+		}
+	}
+	return code, comment, blank
+}
+
+// CountLOC returns the number of lines in the files of p.
+// Lines with code and comments are code lines.
+// The syntax of the files is used if loaded, and otherwise the files are read.
+// It requires files.
+func CountLOC(p *packages.Package) (*LOCResult, error) {
+	if err := need(p, packages.NeedFiles); err != nil {
+		return nil, err
+	}
+	syntax := map[string]*ast.File{}
+	if p.Fset != nil {
+		for _, f := range p.Syntax {
+			if file := p.Fset.File(f.Pos()); file != nil {
+				syntax[file.Name()] = f
+			}
+		}
+	}
+	var r LOCResult
+	for _, filename := range p.GoFiles {
+		var code, comment, blank int
+		if f, ok := syntax[filename]; ok {
+			code, comment, blank = syntaxLines(p.Fset, f)
+		} else {
+			src, err := os.ReadFile(filename)
+			if err != nil {
+				return nil, err
+			}
+			code, comment, blank = countLines(filename, src)
+		}
+		if strings.HasSuffix(filename, "_test.go") {
+			r.TestCode += code
+			r.TestComment += comment
+			r.TestBlank += blank
+		} else {
+			r.Code += code
+			r.Comment += comment
+			r.Blank += blank
+			r.Total += code + comment + blank
+		}
+	}
+	return &r, nil
+}
@@ -0,0 +1,39 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestCountLOC(t *testing.T) {
+	t.Parallel()
+	r, err := CountLOC(loadTestdata(t, "loc"))
+	require.NoError(t, err)
+	assert.Equal(t, &LOCResult{Code: 5, Comment: 3, Blank: 1, Total: 9}, r)
+	p, err := LoadTestPackage("./testdata/loc")
+	require.NoError(t, err)
+	r, err = CountLOC(p)
+	require.NoError(t, err)
+	assert.Equal(t, &LOCResult{Code: 5, Comment: 3, Blank: 1, Total: 9, TestCode: 3, TestComment: 1, TestBlank: 2}, r)
+	_, err = CountLOC(&packages.Package{})
+	assert.ErrorIs(t, err, ErrNotLoaded)
+}
+
+func TestCountLOCSyntax(t *testing.T) {
+	t.Parallel()
+	for _, path := range []string{"./testdata/loc", "encoding/json", "go/types"} {
+		t.Run(path, func(t *testing.T) {
+			t.Parallel()
+			p, err := LoadTestPackage(path)
+			require.NoError(t, err)
+			want, err := CountLOC(&packages.Package{GoFiles: p.GoFiles})
+			require.NoError(t, err)
+			got, err := CountLOC(p)
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
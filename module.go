@@ -0,0 +1,101 @@
+package forklift
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// ErrNoModule means the package module is unknown,
+// either because the package is not in a module,
+// or because the module was not loaded.
+var ErrNoModule = fmt.Errorf("package module unknown")
+
+// PackageVersion returns the module version of p.
+// The version is empty for the main module.
+// It returns [ErrNoModule] if the module is unknown.
+// It requires the module.
+func PackageVersion(p *packages.Package) (string, error) {
+	if p == nil {
+		return "", ErrNotFound
+	}
+	if p.Module == nil {
+		return "", ErrNoModule
+	}
+	return p.Module.Version, nil
+}
+
+// LoadPackageVersion returns the module version of the package for path.
+// The version is empty for the main module.
+// It returns [ErrNotFound] if the package is not found,
+// [ErrNoModule] if the module is unknown, and other errors.
+func LoadPackageVersion(path string) (string, error) {
+	p, err := Loader{Mode: packages.NeedName | packages.NeedModule}.LoadPackage(path)
+	if err != nil {
+		return "", err
+	}
+	return PackageVersion(p)
+}
+
+// ReplaceDirective is a module replacement.
+type ReplaceDirective struct {
+	// OldPath is the replaced module path.
+	OldPath string
+
+	// OldVersion is the required version of the replaced module.
+	OldVersion string
+
+	// NewPath is the replacement module path or directory.
+	NewPath string
+
+	// NewVersion is the replacement module version, or empty for a directory.
+	NewVersion string
+
+	// IsLocal is whether NewPath is a directory.
+	IsLocal bool
+}
+
+// isDirectoryPath returns whether the module replacement path is a directory.
+func isDirectoryPath(path string) bool {
+	return path == "." || path == ".." || strings.HasPrefix(path, "./") || strings.HasPrefix(path, "../") ||
+		strings.HasPrefix(path, `.\`) || strings.HasPrefix(path, `..\`) || filepath.IsAbs(path)
+}
+
+// ExtractModuleReplace returns the module replacements used by p and its dependencies, sorted by old path.
+// It requires the module, and imports and dependencies to include the replacements of dependencies.
+func ExtractModuleReplace(p *packages.Package) ([]ReplaceDirective, error) {
+	if p == nil {
+		return nil, ErrNotFound
+	}
+	seen := map[*packages.Package]bool{}
+	modules := map[string]*packages.Module{}
+	var visit func(*packages.Package)
+	visit = func(p *packages.Package) {
+		if seen[p] {
+			return
+		}
+		seen[p] = true
+		if p.Module != nil && p.Module.Replace != nil {
+			modules[p.Module.Path] = p.Module
+		}
+		for _, imp := range p.Imports {
+			visit(imp)
+		}
+	}
+	visit(p)
+	var directives []ReplaceDirective
+	for _, m := range modules {
+		directives = append(directives, ReplaceDirective{
+			OldPath:    m.Path,
+			OldVersion: m.Version,
+			NewPath:    m.Replace.Path,
+			NewVersion: m.Replace.Version,
+			IsLocal:    isDirectoryPath(m.Replace.Path),
+		})
+	}
+	sort.Slice(directives, func(i, j int) bool { return directives[i].OldPath < directives[j].OldPath })
+	return directives, nil
+}
@@ -0,0 +1,46 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestPackageVersion(t *testing.T) {
+	t.Parallel()
+	v, err := PackageVersion(loadTestdata(t, "initorder"))
+	assert.NoError(t, err)
+	assert.Equal(t, "", v)
+	p, err := LoadPackage("fmt")
+	assert.NoError(t, err)
+	_, err = PackageVersion(p)
+	assert.Equal(t, ErrNoModule, err)
+}
+
+func TestLoadPackageVersion(t *testing.T) {
+	t.Parallel()
+	v, err := LoadPackageVersion("github.com/stretchr/testify/assert")
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.9.0", v)
+	_, err = LoadPackageVersion("fmt")
+	assert.Equal(t, ErrNoModule, err)
+	_, err = LoadPackageVersion("bad")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestExtractModuleReplace(t *testing.T) {
+	t.Parallel()
+	p, err := Loader{Dir: "testdata/replacemod", Mode: packages.NeedName | packages.NeedModule | packages.NeedImports | packages.NeedDeps}.LoadPackage(".")
+	require.NoError(t, err)
+	directives, err := ExtractModuleReplace(p)
+	require.NoError(t, err)
+	assert.Equal(t, []ReplaceDirective{
+		{OldPath: "example.com/dep", OldVersion: "v1.0.0", NewPath: "./dep", IsLocal: true},
+		{OldPath: "example.com/dep2", OldVersion: "v1.2.0", NewPath: "./dep2", IsLocal: true},
+	}, directives)
+	directives, err = ExtractModuleReplace(loadTestdata(t, "typegraph"))
+	require.NoError(t, err)
+	assert.Empty(t, directives)
+}
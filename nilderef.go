@@ -0,0 +1,137 @@
+package forklift
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// NilDeref is a pointer dereference without a preceding nil check.
+type NilDeref struct {
+	// Expr is the dereference expression, like "*x" or "x.Field".
+	Expr string
+
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+}
+
+// NilDerefFinder finds pointer dereferences without preceding nil checks.
+type NilDerefFinder struct {
+	// ParamsOnly is whether to only consider function parameters.
+	ParamsOnly bool
+}
+
+// FindNilDerefRisks returns the dereferences of local pointer variables in p
+// that are not preceded by a comparison of the variable with nil in the same function.
+// Method receivers are excluded.
+// This is a heuristic: it does not prove that a dereference is safe or unsafe.
+// It requires syntax and types info.
+func (f NilDerefFinder) FindNilDerefRisks(p *packages.Package) ([]NilDeref, error) {
+	if err := need(p, packages.NeedSyntax|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	var derefs []NilDeref
+	pointer := func(x ast.Expr) *types.Var {
+		id, ok := ast.Unparen(x).(*ast.Ident)
+		if !ok {
+			return nil
+		}
+		v, ok := p.TypesInfo.Uses[id].(*types.Var)
+		if !ok || v.IsField() || v.Parent() == p.Types.Scope() {
+			return nil
+		}
+		if _, ok := v.Type().Underlying().(*types.Pointer); !ok {
+			return nil
+		}
+		return v
+	}
+	for _, file := range p.Syntax {
+		for _, d := range file.Decls {
+			d, ok := d.(*ast.FuncDecl)
+			if !ok || d.Body == nil {
+				continue
+			}
+			params := map[*types.Var]bool{}
+			receivers := map[*types.Var]bool{}
+			guards := map[*types.Var]token.Pos{}
+			if d.Recv != nil {
+				for _, field := range d.Recv.List {
+					for _, name := range field.Names {
+						if v, ok := p.TypesInfo.Defs[name].(*types.Var); ok {
+							receivers[v] = true
+						}
+					}
+				}
+			}
+			ast.Inspect(d, func(n ast.Node) bool {
+				switch n := n.(type) {
+				case *ast.FuncType:
+					for _, field := range n.Params.List {
+						for _, name := range field.Names {
+							if v, ok := p.TypesInfo.Defs[name].(*types.Var); ok {
+								params[v] = true
+							}
+						}
+					}
+				case *ast.BinaryExpr:
+					if n.Op != token.EQL && n.Op != token.NEQ {
+						break
+					}
+					for _, pair := range [][2]ast.Expr{{n.X, n.Y}, {n.Y, n.X}} {
+						if v := pointer(pair[0]); v != nil && p.TypesInfo.Types[pair[1]].IsNil() {
+							if pos, ok := guards[v]; !ok || n.Pos() < pos {
+								guards[v] = n.Pos()
+							}
+						}
+					}
+				}
+				return true
+			})
+			ast.Inspect(d.Body, func(n ast.Node) bool {
+				var v *types.Var
+				switch n := n.(type) {
+				case *ast.StarExpr:
+					if p.TypesInfo.Types[n].IsType() {
+						return true
+					}
+					v = pointer(n.X)
+				case *ast.SelectorExpr:
+					sel := p.TypesInfo.Selections[n]
+					if sel == nil {
+						return true
+					}
+					if sel.Kind() == types.MethodVal {
+						if _, ok := sel.Obj().Type().(*types.Signature).Recv().Type().(*types.Pointer); ok {
+							return true
+						}
+					}
+					v = pointer(n.X)
+				}
+				if v == nil || receivers[v] || f.ParamsOnly && !params[v] {
+					return true
+				}
+				if pos, ok := guards[v]; ok && pos < n.Pos() {
+					return true
+				}
+				file, line := position(p, n.Pos())
+				derefs = append(derefs, NilDeref{Expr: types.ExprString(n.(ast.Expr)), File: file, Line: line})
+				return true
+			})
+		}
+	}
+	return derefs, nil
+}
+
+// FindNilDerefRisks returns the dereferences of local pointer variables in p
+// that are not preceded by a comparison of the variable with nil in the same function.
+// Method receivers are excluded.
+// This is a heuristic: it does not prove that a dereference is safe or unsafe.
+// It requires syntax and types info.
+func FindNilDerefRisks(p *packages.Package) ([]NilDeref, error) {
+	return NilDerefFinder{}.FindNilDerefRisks(p)
+}
@@ -0,0 +1,27 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindNilDerefRisks(t *testing.T) {
+	t.Parallel()
+	p := loadTestdata(t, "nilderef")
+	derefs, err := FindNilDerefRisks(p)
+	require.NoError(t, err)
+	var exprs []string
+	for _, d := range derefs {
+		exprs = append(exprs, d.Expr)
+	}
+	assert.Equal(t, []string{"a.n", "*c", "d.n", "a.value"}, exprs)
+	derefs, err = NilDerefFinder{ParamsOnly: true}.FindNilDerefRisks(p)
+	require.NoError(t, err)
+	exprs = nil
+	for _, d := range derefs {
+		exprs = append(exprs, d.Expr)
+	}
+	assert.Equal(t, []string{"a.n", "*c", "a.value"}, exprs)
+}
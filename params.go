@@ -0,0 +1,62 @@
+package forklift
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// UnusedParam is a function parameter that is never referenced.
+type UnusedParam struct {
+	// FuncName is the function name. Methods are qualified by their receiver type name.
+	FuncName string
+
+	// ParamName is the parameter name.
+	ParamName string
+
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+}
+
+// FindUnusedParameters returns the named function parameters in p that are never referenced
+// in the function body. Blank and unnamed parameters and functions without bodies are excluded.
+// It requires syntax and types info.
+func FindUnusedParameters(p *packages.Package) ([]UnusedParam, error) {
+	if err := need(p, packages.NeedSyntax|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	used := map[types.Object]bool{}
+	for _, obj := range p.TypesInfo.Uses {
+		used[obj] = true
+	}
+	var unused []UnusedParam
+	check := func(fn string, params *ast.FieldList) {
+		for _, field := range params.List {
+			for _, name := range field.Names {
+				if name.Name == "_" {
+					continue
+				}
+				if obj := p.TypesInfo.Defs[name]; obj != nil && !used[obj] {
+					file, line := position(p, name.Pos())
+					unused = append(unused, UnusedParam{FuncName: fn, ParamName: name.Name, File: file, Line: line})
+				}
+			}
+		}
+	}
+	inspect(p, func(n ast.Node, fn string) bool {
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			if n.Body != nil {
+				check(fn, n.Type.Params)
+			}
+		case *ast.FuncLit:
+			check(fn, n.Type.Params)
+		}
+		return true
+	})
+	return unused, nil
+}
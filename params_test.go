@@ -0,0 +1,22 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindUnusedParameters(t *testing.T) {
+	t.Parallel()
+	unused, err := FindUnusedParameters(loadTestdata(t, "params"))
+	require.NoError(t, err)
+	for i := range unused {
+		unused[i].File = ""
+	}
+	assert.Equal(t, []UnusedParam{
+		{FuncName: "T.method", ParamName: "b", Line: 5},
+		{FuncName: "g", ParamName: "unused", Line: 13},
+		{FuncName: "", ParamName: "z", Line: 17},
+	}, unused)
+}
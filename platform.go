@@ -0,0 +1,61 @@
+package forklift
+
+import (
+	"os"
+	"sync"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Platform is a build target.
+type Platform struct {
+	// GOOS is the operating system.
+	GOOS string
+
+	// GOARCH is the architecture.
+	GOARCH string
+}
+
+// PlatformResult is the result of loading a package for a platform.
+type PlatformResult struct {
+	// Platform is the platform.
+	Platform Platform
+
+	// Package is the package, or nil if Err is set.
+	Package *packages.Package
+
+	// Err is the error loading the package.
+	Err error
+}
+
+// LoadPlatformMatrix returns the packages for path for each of platforms, in the same order.
+// The packages are loaded concurrently.
+// Errors loading a package are in the results.
+func (l Loader) LoadPlatformMatrix(path string, platforms []Platform) ([]*PlatformResult, error) {
+	env := l.Env
+	if env == nil {
+		env = os.Environ()
+	}
+	results := make([]*PlatformResult, len(platforms))
+	var wg sync.WaitGroup
+	for i, platform := range platforms {
+		i, platform := i, platform
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l := l
+			l.Env = append(env[:len(env):len(env)], "GOOS="+platform.GOOS, "GOARCH="+platform.GOARCH)
+			p, err := l.LoadPackage(path)
+			results[i] = &PlatformResult{Platform: platform, Package: p, Err: err}
+		}()
+	}
+	wg.Wait()
+	return results, nil
+}
+
+// LoadPlatformMatrix returns the packages for path for each of platforms, in the same order.
+// The packages are loaded concurrently.
+// Errors loading a package are in the results.
+func LoadPlatformMatrix(path string, platforms []Platform) ([]*PlatformResult, error) {
+	return Loader{Mode: DefaultMode}.LoadPlatformMatrix(path, platforms)
+}
@@ -0,0 +1,33 @@
+package forklift
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestLoadPlatformMatrix(t *testing.T) {
+	t.Parallel()
+	platforms := []Platform{{GOOS: "linux", GOARCH: "amd64"}, {GOOS: "windows", GOARCH: "arm64"}}
+	results, err := Loader{Mode: packages.NeedName | packages.NeedFiles}.LoadPlatformMatrix("os", platforms)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for i, r := range results {
+		assert.Equal(t, platforms[i], r.Platform)
+	}
+	require.NoError(t, results[0].Err)
+	require.NoError(t, results[1].Err)
+	var linux, windows []string
+	for _, f := range results[0].Package.GoFiles {
+		linux = append(linux, filepath.Base(f))
+	}
+	for _, f := range results[1].Package.GoFiles {
+		windows = append(windows, filepath.Base(f))
+	}
+	assert.Contains(t, linux, "file_unix.go")
+	assert.NotContains(t, windows, "file_unix.go")
+	assert.Contains(t, windows, "file_windows.go")
+}
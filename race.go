@@ -0,0 +1,203 @@
+package forklift
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"go/version"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// RacePattern is code that is prone to data races.
+type RacePattern struct {
+	// Kind is the pattern kind:
+	// "map-write" for writes to package-level maps in goroutine function literals that do not lock,
+	// "loop-var-capture" for loop variables captured by goroutine function literals before Go 1.22,
+	// or "shared-rand" for calls of *math/rand.Rand methods on package-level variables in functions that do not lock.
+	Kind string
+
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+
+	// Detail is the variable name.
+	Detail string
+}
+
+// FindRacePronePatterns returns the code in p that is prone to data races, sorted by position.
+// It requires syntax, types, and types info.
+func FindRacePronePatterns(p *packages.Package) ([]RacePattern, error) {
+	if err := need(p, packages.NeedSyntax|packages.NeedTypes|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	var races []RacePattern
+	add := func(pos token.Pos, kind, name string) {
+		file, line := position(p, pos)
+		races = append(races, RacePattern{Kind: kind, File: file, Line: line, Detail: name})
+	}
+	for _, f := range p.Syntax {
+		v := p.TypesInfo.FileVersions[f]
+		if v == "" && p.Module != nil && p.Module.GoVersion != "" {
+			v = "go" + p.Module.GoVersion
+		}
+		loopVars := v == "" || version.Compare(v, "go1.22") < 0
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncDecl:
+				if n.Body == nil || locks(n.Body) {
+					return true
+				}
+				ast.Inspect(n.Body, func(n ast.Node) bool {
+					if call, ok := n.(*ast.CallExpr); ok {
+						if v := sharedRand(p, call); v != nil {
+							add(call.Pos(), "shared-rand", v.Name())
+						}
+					}
+					return true
+				})
+			case *ast.GoStmt:
+				lit, ok := n.Call.Fun.(*ast.FuncLit)
+				if !ok || locks(lit.Body) {
+					return true
+				}
+				ast.Inspect(lit.Body, func(n ast.Node) bool {
+					var maps []ast.Expr
+					switch n := n.(type) {
+					case *ast.AssignStmt:
+						maps = indexed(n.Lhs...)
+					case *ast.IncDecStmt:
+						maps = indexed(n.X)
+					case *ast.CallExpr:
+						if b, ok := typeutil.Callee(p.TypesInfo, n).(*types.Builtin); ok && b.Name() == "delete" && len(n.Args) > 0 {
+							maps = n.Args[:1]
+						}
+					}
+					for _, m := range maps {
+						if v := packageVar(p, m); v != nil {
+							if _, ok := v.Type().Underlying().(*types.Map); ok {
+								add(m.Pos(), "map-write", v.Name())
+							}
+						}
+					}
+					return true
+				})
+			case *ast.ForStmt:
+				if loopVars {
+					if init, ok := n.Init.(*ast.AssignStmt); ok && init.Tok == token.DEFINE {
+						for _, v := range capturedLoopVars(p, n.Body, init.Lhs) {
+							add(v.pos, "loop-var-capture", v.name)
+						}
+					}
+				}
+			case *ast.RangeStmt:
+				if loopVars && n.Tok == token.DEFINE {
+					for _, v := range capturedLoopVars(p, n.Body, []ast.Expr{n.Key, n.Value}) {
+						add(v.pos, "loop-var-capture", v.name)
+					}
+				}
+			}
+			return true
+		})
+	}
+	sort.SliceStable(races, func(i, j int) bool {
+		if races[i].File != races[j].File {
+			return races[i].File < races[j].File
+		}
+		return races[i].Line < races[j].Line
+	})
+	return races, nil
+}
+
+// locks returns whether n calls a Lock or RLock method.
+func locks(n ast.Node) bool {
+	var found bool
+	ast.Inspect(n, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok && (sel.Sel.Name == "Lock" || sel.Sel.Name == "RLock") {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// indexed returns the indexed expressions of the index expressions in es.
+func indexed(es ...ast.Expr) []ast.Expr {
+	var xs []ast.Expr
+	for _, e := range es {
+		if index, ok := e.(*ast.IndexExpr); ok {
+			xs = append(xs, index.X)
+		}
+	}
+	return xs
+}
+
+// packageVar returns the package-level variable that e refers to, or nil if none.
+func packageVar(p *packages.Package, e ast.Expr) *types.Var {
+	id, ok := ast.Unparen(e).(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	v, ok := p.TypesInfo.Uses[id].(*types.Var)
+	if !ok || v.Parent() != p.Types.Scope() {
+		return nil
+	}
+	return v
+}
+
+// sharedRand returns the package-level variable that is the receiver of call if call is a *math/rand.Rand method call,
+// or nil otherwise.
+func sharedRand(p *packages.Package, call *ast.CallExpr) *types.Var {
+	f, ok := typeutil.Callee(p.TypesInfo, call).(*types.Func)
+	if !ok || f.Pkg() == nil || f.Pkg().Path() != "math/rand" && f.Pkg().Path() != "math/rand/v2" {
+		return nil
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || funcName(f) != "Rand."+f.Name() {
+		return nil
+	}
+	return packageVar(p, sel.X)
+}
+
+// capturedVar is a variable use.
+type capturedVar struct {
+	pos  token.Pos
+	name string
+}
+
+// capturedLoopVars returns the uses of the variables declared by vars in goroutine function literals in body.
+func capturedLoopVars(p *packages.Package, body *ast.BlockStmt, vars []ast.Expr) []capturedVar {
+	loop := map[types.Object]bool{}
+	for _, v := range vars {
+		if id, ok := v.(*ast.Ident); ok && p.TypesInfo.Defs[id] != nil {
+			loop[p.TypesInfo.Defs[id]] = true
+		}
+	}
+	var captured []capturedVar
+	ast.Inspect(body, func(n ast.Node) bool {
+		g, ok := n.(*ast.GoStmt)
+		if !ok {
+			return true
+		}
+		if lit, ok := g.Call.Fun.(*ast.FuncLit); ok {
+			seen := map[types.Object]bool{}
+			ast.Inspect(lit.Body, func(n ast.Node) bool {
+				if id, ok := n.(*ast.Ident); ok {
+					if obj := p.TypesInfo.Uses[id]; loop[obj] && !seen[obj] {
+						seen[obj] = true
+						captured = append(captured, capturedVar{id.Pos(), id.Name})
+					}
+				}
+				return true
+			})
+		}
+		return true
+	})
+	return captured
+}
@@ -0,0 +1,24 @@
+package forklift
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindRacePronePatterns(t *testing.T) {
+	t.Parallel()
+	patterns, err := FindRacePronePatterns(loadTestdata(t, "races"))
+	require.NoError(t, err)
+	for i := range patterns {
+		patterns[i].File = filepath.Base(patterns[i].File)
+	}
+	assert.Equal(t, []RacePattern{
+		{Kind: "loop-var-capture", File: "old.go", Line: 8, Detail: "i"},
+		{Kind: "map-write", File: "races.go", Line: 16, Detail: "cache"},
+		{Kind: "map-write", File: "races.go", Line: 17, Detail: "cache"},
+		{Kind: "shared-rand", File: "races.go", Line: 32, Detail: "rng"},
+	}, patterns)
+}
@@ -0,0 +1,63 @@
+package forklift
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// NonLiteralPattern is the [HTTPRoute] pattern for patterns that are not constant strings.
+const NonLiteralPattern = "<non-literal>"
+
+// HTTPRoute is an HTTP handler registration.
+type HTTPRoute struct {
+	// Pattern is the route pattern, or [NonLiteralPattern].
+	Pattern string
+
+	// HandlerName is the handler expression.
+	HandlerName string
+
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+}
+
+// ExtractHTTPRoutes returns the calls in p to the net/http Handle and HandleFunc functions
+// and ServeMux methods.
+// It requires syntax and types info.
+func ExtractHTTPRoutes(p *packages.Package) ([]HTTPRoute, error) {
+	if err := need(p, packages.NeedSyntax|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	var routes []HTTPRoute
+	for _, f := range p.Syntax {
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) != 2 {
+				return true
+			}
+			fn := typeutil.StaticCallee(p.TypesInfo, call)
+			if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != "net/http" {
+				return true
+			}
+			switch funcName(fn) {
+			case "Handle", "HandleFunc", "ServeMux.Handle", "ServeMux.HandleFunc":
+			default:
+				return true
+			}
+			pattern := NonLiteralPattern
+			if v := p.TypesInfo.Types[call.Args[0]].Value; v != nil && v.Kind() == constant.String {
+				pattern = constant.StringVal(v)
+			}
+			file, line := position(p, call.Pos())
+			routes = append(routes, HTTPRoute{Pattern: pattern, HandlerName: types.ExprString(call.Args[1]), File: file, Line: line})
+			return true
+		})
+	}
+	return routes, nil
+}
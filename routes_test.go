@@ -0,0 +1,23 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractHTTPRoutes(t *testing.T) {
+	t.Parallel()
+	routes, err := ExtractHTTPRoutes(loadTestdata(t, "routes"))
+	require.NoError(t, err)
+	for i := range routes {
+		routes[i].File = ""
+	}
+	assert.Equal(t, []HTTPRoute{
+		{Pattern: "/", HandlerName: "index", Line: 10},
+		{Pattern: "/api/", HandlerName: "http.NotFoundHandler()", Line: 11},
+		{Pattern: NonLiteralPattern, HandlerName: "index", Line: 12},
+		{Pattern: "/static/", HandlerName: `http.FileServer(http.Dir("."))`, Line: 13},
+	}, routes)
+}
@@ -0,0 +1,71 @@
+package forklift
+
+import (
+	"go/types"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// ShadowedVar is a local variable declaration that shadows an outer local variable
+// in the same function.
+type ShadowedVar struct {
+	// Name is the variable name.
+	Name string
+
+	// OuterFile is the outer variable file name.
+	OuterFile string
+
+	// OuterLine is the outer variable line number.
+	OuterLine int
+
+	// InnerFile is the inner variable file name.
+	InnerFile string
+
+	// InnerLine is the inner variable line number.
+	InnerLine int
+}
+
+// FindShadowedVariables returns the local variable declarations in p that shadow
+// outer local variables and parameters. Package-level variables are not considered shadowed.
+// It requires types and types info.
+func FindShadowedVariables(p *packages.Package) ([]ShadowedVar, error) {
+	if err := need(p, packages.NeedTypes|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	var shadowed []ShadowedVar
+	for _, obj := range p.TypesInfo.Defs {
+		inner, ok := obj.(*types.Var)
+		if !ok || inner.IsField() || inner.Name() == "_" || inner.Parent() == nil || inner.Parent() == p.Types.Scope() {
+			continue
+		}
+		scope := inner.Parent().Parent()
+		if scope == nil || scope == p.Types.Scope() {
+			continue
+		}
+		_, o := scope.LookupParent(inner.Name(), inner.Pos())
+		outer, ok := o.(*types.Var)
+		if !ok || outer.Parent() == p.Types.Scope() || outer.Parent() == types.Universe || outer.Pkg() != p.Types {
+			continue
+		}
+		outerFile, outerLine := position(p, outer.Pos())
+		innerFile, innerLine := position(p, inner.Pos())
+		shadowed = append(shadowed, ShadowedVar{
+			Name:      inner.Name(),
+			OuterFile: outerFile,
+			OuterLine: outerLine,
+			InnerFile: innerFile,
+			InnerLine: innerLine,
+		})
+	}
+	sort.Slice(shadowed, func(i, j int) bool {
+		if shadowed[i].InnerFile != shadowed[j].InnerFile {
+			return shadowed[i].InnerFile < shadowed[j].InnerFile
+		}
+		if shadowed[i].InnerLine != shadowed[j].InnerLine {
+			return shadowed[i].InnerLine < shadowed[j].InnerLine
+		}
+		return shadowed[i].Name < shadowed[j].Name
+	})
+	return shadowed, nil
+}
@@ -0,0 +1,22 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindShadowedVariables(t *testing.T) {
+	t.Parallel()
+	shadowed, err := FindShadowedVariables(loadTestdata(t, "shadow"))
+	require.NoError(t, err)
+	for i := range shadowed {
+		shadowed[i].OuterFile, shadowed[i].InnerFile = "", ""
+	}
+	assert.Equal(t, []ShadowedVar{
+		{Name: "err", OuterLine: 8, InnerLine: 10},
+		{Name: "n", OuterLine: 8, InnerLine: 10},
+		{Name: "s", OuterLine: 7, InnerLine: 15},
+	}, shadowed)
+}
@@ -0,0 +1,200 @@
+package forklift
+
+import (
+	"go/types"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// funcs returns the package-level functions in p and the methods of the package-level named types in p,
+// sorted by name, with methods following their types.
+func funcs(p *packages.Package) []*types.Func {
+	var fs []*types.Func
+	scope := p.Types.Scope()
+	for _, name := range scope.Names() {
+		switch obj := scope.Lookup(name).(type) {
+		case *types.Func:
+			fs = append(fs, obj)
+		case *types.TypeName:
+			if named, ok := obj.Type().(*types.Named); ok && !obj.IsAlias() {
+				for i := 0; i < named.NumMethods(); i++ {
+					fs = append(fs, named.Method(i))
+				}
+			}
+		}
+	}
+	return fs
+}
+
+// FindVariadicFunctions returns the names of the variadic functions and methods in p.
+// Methods are qualified by their receiver type name.
+// It requires types.
+func FindVariadicFunctions(p *packages.Package) ([]string, error) {
+	if err := need(p, packages.NeedTypes); err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, f := range funcs(p) {
+		if f.Type().(*types.Signature).Variadic() {
+			names = append(names, funcName(f))
+		}
+	}
+	return names, nil
+}
+
+// NamedReturnFunc is a function with named results.
+type NamedReturnFunc struct {
+	// Name is the function name. Methods are qualified by their receiver type name.
+	Name string
+
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+
+	// ReturnNames is the result names.
+	ReturnNames []string
+}
+
+// FindNamedReturns returns the functions and methods in p with named results.
+// It requires types.
+func FindNamedReturns(p *packages.Package) ([]NamedReturnFunc, error) {
+	if err := need(p, packages.NeedTypes); err != nil {
+		return nil, err
+	}
+	var named []NamedReturnFunc
+	for _, f := range funcs(p) {
+		results := f.Type().(*types.Signature).Results()
+		if results.Len() == 0 || results.At(0).Name() == "" {
+			continue
+		}
+		names := make([]string, results.Len())
+		for i := range names {
+			names[i] = results.At(i).Name()
+		}
+		file, line := position(p, f.Pos())
+		named = append(named, NamedReturnFunc{Name: funcName(f), File: file, Line: line, ReturnNames: names})
+	}
+	return named, nil
+}
+
+// FindLargeFunctionArgLists returns the names of the exported functions and methods in p
+// with more than maxParams parameters, not counting receivers and variadic parameters.
+// Methods are qualified by their receiver type name.
+// It requires types.
+func FindLargeFunctionArgLists(p *packages.Package, maxParams int) ([]string, error) {
+	if err := need(p, packages.NeedTypes); err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, f := range funcs(p) {
+		if !f.Exported() || !exportedRecv(f) {
+			continue
+		}
+		sig := f.Type().(*types.Signature)
+		n := sig.Params().Len()
+		if sig.Variadic() {
+			n--
+		}
+		if n > maxParams {
+			names = append(names, funcName(f))
+		}
+	}
+	return names, nil
+}
+
+// MultiReturnFunc is a function with multiple results.
+type MultiReturnFunc struct {
+	// Name is the function name. Methods are qualified by their receiver type name.
+	Name string
+
+	// NumReturns is the number of results.
+	NumReturns int
+}
+
+// FindMultiReturnFunctions returns the exported functions and methods in p
+// with at least minReturns results.
+// It requires types.
+func FindMultiReturnFunctions(p *packages.Package, minReturns int) ([]MultiReturnFunc, error) {
+	if err := need(p, packages.NeedTypes); err != nil {
+		return nil, err
+	}
+	var multi []MultiReturnFunc
+	for _, f := range funcs(p) {
+		if !f.Exported() || !exportedRecv(f) {
+			continue
+		}
+		if n := f.Type().(*types.Signature).Results().Len(); n >= minReturns {
+			multi = append(multi, MultiReturnFunc{Name: funcName(f), NumReturns: n})
+		}
+	}
+	return multi, nil
+}
+
+// exportedRecv returns whether f is a function or a method of an exported type.
+func exportedRecv(f *types.Func) bool {
+	recv := f.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return true
+	}
+	t := recv.Type()
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Exported()
+}
+
+// DIPoint is a constructor function.
+type DIPoint struct {
+	// ConstructorName is the function name.
+	ConstructorName string
+
+	// Params is the parameters.
+	Params []DIParam
+}
+
+// DIParam is a constructor parameter.
+type DIParam struct {
+	// Name is the parameter name, or empty if it is unnamed.
+	Name string
+
+	// TypeString is the parameter type, like "io.Writer" or "...string".
+	TypeString string
+}
+
+// FindDependencyInjectionPoints returns the constructor functions in p, named like "New" or "NewType".
+// Types in other packages are qualified by their package path.
+// It requires types.
+func FindDependencyInjectionPoints(p *packages.Package) ([]DIPoint, error) {
+	if err := need(p, packages.NeedTypes); err != nil {
+		return nil, err
+	}
+	var points []DIPoint
+	qualifier := types.RelativeTo(p.Types)
+	for _, f := range funcs(p) {
+		name := f.Name()
+		sig := f.Type().(*types.Signature)
+		if sig.Recv() != nil || !strings.HasPrefix(name, "New") {
+			continue
+		}
+		if r, _ := utf8.DecodeRuneInString(name[len("New"):]); unicode.IsLower(r) {
+			continue
+		}
+		params := make([]DIParam, sig.Params().Len())
+		for i := range params {
+			v := sig.Params().At(i)
+			typ := types.TypeString(v.Type(), qualifier)
+			if sig.Variadic() && i == len(params)-1 {
+				typ = "..." + types.TypeString(v.Type().(*types.Slice).Elem(), qualifier)
+			}
+			params[i] = DIParam{Name: v.Name(), TypeString: typ}
+		}
+		points = append(points, DIPoint{ConstructorName: name, Params: params})
+	}
+	return points, nil
+}
@@ -0,0 +1,67 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindVariadicFunctions(t *testing.T) {
+	t.Parallel()
+	names, err := FindVariadicFunctions(loadTestdata(t, "signatures"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Append", "T.Printf", "T.Sum"}, names)
+}
+
+func TestFindNamedReturns(t *testing.T) {
+	t.Parallel()
+	named, err := FindNamedReturns(loadTestdata(t, "signatures"))
+	require.NoError(t, err)
+	for i := range named {
+		named[i].File = ""
+	}
+	assert.Equal(t, []NamedReturnFunc{
+		{Name: "Fixed", Line: 11, ReturnNames: []string{"q", "r", "err"}},
+		{Name: "T.Sum", Line: 7, ReturnNames: []string{"total"}},
+	}, named)
+}
+
+func TestFindLargeFunctionArgLists(t *testing.T) {
+	t.Parallel()
+	names, err := FindLargeFunctionArgLists(loadTestdata(t, "signatures"), 3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Many", "T.Sum"}, names)
+}
+
+func TestFindMultiReturnFunctions(t *testing.T) {
+	t.Parallel()
+	p := loadTestdata(t, "signatures")
+	multi, err := FindMultiReturnFunctions(p, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []MultiReturnFunc{{Name: "Fixed", NumReturns: 3}, {Name: "Many", NumReturns: 4}}, multi)
+	multi, err = FindMultiReturnFunctions(p, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []MultiReturnFunc{
+		{Name: "Append", NumReturns: 1},
+		{Name: "Fixed", NumReturns: 3},
+		{Name: "Many", NumReturns: 4},
+		{Name: "T.Sum", NumReturns: 1},
+	}, multi)
+}
+
+func TestFindDependencyInjectionPoints(t *testing.T) {
+	t.Parallel()
+	points, err := FindDependencyInjectionPoints(loadTestdata(t, "constructors"))
+	require.NoError(t, err)
+	assert.Equal(t, []DIPoint{
+		{ConstructorName: "New", Params: []DIParam{}},
+		{ConstructorName: "NewReader", Params: []DIParam{{TypeString: "io.Reader"}}},
+		{ConstructorName: "NewService", Params: []DIParam{
+			{Name: "w", TypeString: "io.Writer"},
+			{Name: "db", TypeString: "*Service"},
+			{Name: "_", TypeString: "int"},
+			{Name: "opts", TypeString: "...string"},
+		}},
+	}, points)
+}
@@ -0,0 +1,119 @@
+package forklift
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// SingletonInfo is a package-level variable that holds a single instance.
+type SingletonInfo struct {
+	// VarName is the variable name.
+	VarName string
+
+	// TypeName is the variable type, without any pointer.
+	TypeName string
+
+	// UsesOnce is whether the variable is assigned in a function passed to sync.Once.Do.
+	UsesOnce bool
+}
+
+// DetectSingleton returns the package-level variables in p that are returned by functions
+// named like "Get", "Instance", or "Default", with struct or struct pointer types,
+// or that are assigned in functions passed to sync.Once.Do, sorted by variable name.
+// It requires syntax, types, and types info.
+func DetectSingleton(p *packages.Package) ([]SingletonInfo, error) {
+	if err := need(p, packages.NeedSyntax|packages.NeedTypes|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	singletons := map[*types.Var]*SingletonInfo{}
+	add := func(v *types.Var, once bool) {
+		s, ok := singletons[v]
+		if !ok {
+			t := v.Type()
+			if ptr, ok := t.(*types.Pointer); ok {
+				t = ptr.Elem()
+			}
+			s = &SingletonInfo{VarName: v.Name(), TypeName: types.TypeString(t, types.RelativeTo(p.Types))}
+			singletons[v] = s
+		}
+		s.UsesOnce = s.UsesOnce || once
+	}
+	for _, f := range p.Syntax {
+		for _, d := range f.Decls {
+			d, ok := d.(*ast.FuncDecl)
+			if !ok || d.Body == nil {
+				continue
+			}
+			accessor := d.Recv == nil && isAccessorName(d.Name.Name)
+			ast.Inspect(d.Body, func(n ast.Node) bool {
+				switch n := n.(type) {
+				case *ast.ReturnStmt:
+					if !accessor {
+						return true
+					}
+					for _, r := range n.Results {
+						if u, ok := r.(*ast.UnaryExpr); ok && u.Op == token.AND {
+							r = u.X
+						}
+						if v := packageVar(p, r); v != nil && isStructOrPointer(v.Type()) {
+							add(v, false)
+						}
+					}
+				case *ast.CallExpr:
+					fn, ok := typeutil.Callee(p.TypesInfo, n).(*types.Func)
+					if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "sync" || funcName(fn) != "Once.Do" || len(n.Args) != 1 {
+						return true
+					}
+					if lit, ok := n.Args[0].(*ast.FuncLit); ok {
+						ast.Inspect(lit.Body, func(n ast.Node) bool {
+							if a, ok := n.(*ast.AssignStmt); ok {
+								for _, l := range a.Lhs {
+									if v := packageVar(p, l); v != nil {
+										add(v, true)
+									}
+								}
+							}
+							return true
+						})
+					}
+				}
+				return true
+			})
+		}
+	}
+	infos := make([]SingletonInfo, 0, len(singletons))
+	for _, s := range singletons {
+		infos = append(infos, *s)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].VarName < infos[j].VarName })
+	return infos, nil
+}
+
+// isAccessorName returns whether name is like "Get", "GetName", "Instance", or "Default".
+func isAccessorName(name string) bool {
+	for _, prefix := range []string{"Get", "Instance", "Default"} {
+		if rest, ok := strings.CutPrefix(name, prefix); ok {
+			if r, _ := utf8.DecodeRuneInString(rest); !unicode.IsLower(r) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isStructOrPointer returns whether t is a struct type or a pointer to one.
+func isStructOrPointer(t types.Type) bool {
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	_, ok := t.Underlying().(*types.Struct)
+	return ok
+}
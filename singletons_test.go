@@ -0,0 +1,19 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectSingleton(t *testing.T) {
+	t.Parallel()
+	singletons, err := DetectSingleton(loadTestdata(t, "singletons"))
+	require.NoError(t, err)
+	assert.Equal(t, []SingletonInfo{
+		{VarName: "config", TypeName: "Config"},
+		{VarName: "names", TypeName: "[]string", UsesOnce: true},
+		{VarName: "reg", TypeName: "registry", UsesOnce: true},
+	}, singletons)
+}
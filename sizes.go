@@ -0,0 +1,123 @@
+package forklift
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// StructSizeInfo is a struct type size.
+type StructSizeInfo struct {
+	// Name is the type name.
+	Name string
+
+	// SizeBytes is the size in bytes.
+	SizeBytes int64
+}
+
+// FindLargeStructs returns the package-level struct types in p larger than maxBytes, sorted by name.
+// Generic types are excluded.
+// It requires types and types sizes.
+func FindLargeStructs(p *packages.Package, maxBytes int64) ([]StructSizeInfo, error) {
+	if err := need(p, packages.NeedTypes|packages.NeedTypesSizes); err != nil {
+		return nil, err
+	}
+	var large []StructSizeInfo
+	scope := p.Types.Scope()
+	for _, name := range scope.Names() {
+		obj, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || obj.IsAlias() {
+			continue
+		}
+		named, ok := obj.Type().(*types.Named)
+		if !ok || named.TypeParams() != nil {
+			continue
+		}
+		if _, ok := named.Underlying().(*types.Struct); !ok {
+			continue
+		}
+		if size := p.TypesSizes.Sizeof(named); size > maxBytes {
+			large = append(large, StructSizeInfo{Name: name, SizeBytes: size})
+		}
+	}
+	return large, nil
+}
+
+// LoadPackageWithCustomTypeSizes returns the package for path, type checked with sizes,
+// like types.SizesFor("gc", "arm"), instead of the sizes for the build system GOARCH.
+// The package syntax is type checked again after loading, so the mode includes syntax, types, types info,
+// types sizes, and imports.
+// Only the package is affected; its dependencies are type checked with the build system sizes.
+// It returns [ErrNotFound] if the package is not found, and other errors.
+func (l Loader) LoadPackageWithCustomTypeSizes(path string, sizes types.Sizes) (*packages.Package, error) {
+	l.Mode |= packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedTypesSizes |
+		packages.NeedImports
+	p, err := l.LoadPackage(path)
+	if err != nil {
+		return nil, err
+	}
+	imports := map[string]*types.Package{}
+	for _, imp := range p.Types.Imports() {
+		imports[imp.Path()] = imp
+	}
+	var errs []error
+	conf := types.Config{
+		Error: func(err error) { errs = append(errs, err) },
+		Importer: importerFunc(func(path string) (*types.Package, error) {
+			if path == "unsafe" {
+				return types.Unsafe, nil
+			}
+			if imp, ok := imports[path]; ok {
+				return imp, nil
+			}
+			// Without dependencies, Imports maps import paths to packages with only IDs,
+			// which are the package paths for vendored packages.
+			if dep, ok := p.Imports[path]; ok {
+				if imp, ok := imports[dep.ID]; ok {
+					return imp, nil
+				}
+			}
+			return nil, fmt.Errorf("cannot import %s", path)
+		}),
+		Sizes: sizes,
+	}
+	if p.Module != nil && p.Module.GoVersion != "" {
+		conf.GoVersion = "go" + p.Module.GoVersion
+	}
+	info := &types.Info{
+		Types:        map[ast.Expr]types.TypeAndValue{},
+		Defs:         map[*ast.Ident]types.Object{},
+		Uses:         map[*ast.Ident]types.Object{},
+		Implicits:    map[ast.Node]types.Object{},
+		Instances:    map[*ast.Ident]types.Instance{},
+		Scopes:       map[ast.Node]*types.Scope{},
+		Selections:   map[*ast.SelectorExpr]*types.Selection{},
+		FileVersions: map[*ast.File]string{},
+	}
+	tp, _ := conf.Check(p.PkgPath, p.Fset, p.Syntax, info)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	p.Types = tp
+	p.TypesInfo = info
+	p.TypesSizes = sizes
+	return p, nil
+}
+
+// LoadPackageWithCustomTypeSizes returns the package for path, type checked with sizes,
+// like types.SizesFor("gc", "arm"), instead of the sizes for the build system GOARCH.
+// It returns [ErrNotFound] if the package is not found, and other errors.
+func LoadPackageWithCustomTypeSizes(path string, sizes types.Sizes) (*packages.Package, error) {
+	return Loader{Mode: DefaultMode}.LoadPackageWithCustomTypeSizes(path, sizes)
+}
+
+// importerFunc is a function that implements types.Importer.
+type importerFunc func(path string) (*types.Package, error)
+
+// Import implements types.Importer.
+func (f importerFunc) Import(path string) (*types.Package, error) {
+	return f(path)
+}
@@ -0,0 +1,47 @@
+package forklift
+
+import (
+	"go/constant"
+	"go/types"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestFindLargeStructs(t *testing.T) {
+	t.Parallel()
+	l := Loader{Mode: packages.NeedName | packages.NeedTypes | packages.NeedTypesSizes, Env: append(os.Environ(), "GOARCH=amd64")}
+	p, err := l.LoadPackage("./testdata/sizes")
+	require.NoError(t, err)
+	large, err := FindLargeStructs(p, 16)
+	require.NoError(t, err)
+	assert.Equal(t, []StructSizeInfo{{Name: "Large", SizeBytes: 72}, {Name: "Padded", SizeBytes: 24}}, large)
+	large, err = FindLargeStructs(p, 0)
+	require.NoError(t, err)
+	assert.Len(t, large, 3)
+	p.TypesSizes = nil
+	_, err = FindLargeStructs(p, 0)
+	assert.ErrorIs(t, err, ErrNotLoaded)
+}
+
+func TestLoadPackageWithCustomTypeSizes(t *testing.T) {
+	t.Parallel()
+	l := Loader{Mode: packages.NeedName, Env: append(os.Environ(), "GOARCH=amd64")}
+	p, err := l.LoadPackageWithCustomTypeSizes("./testdata/sizes", types.SizesFor("gc", "386"))
+	require.NoError(t, err)
+	c, ok := p.Types.Scope().Lookup("PtrSize").(*types.Const)
+	require.True(t, ok)
+	size, _ := constant.Int64Val(c.Val())
+	assert.Equal(t, int64(4), size)
+	large, err := FindLargeStructs(p, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []StructSizeInfo{{Name: "Large", SizeBytes: 72}, {Name: "Padded", SizeBytes: 16}, {Name: "Small", SizeBytes: 8}}, large)
+	p, err = l.LoadPackageWithCustomTypeSizes("./testdata/calls/caller", types.SizesFor("gc", "arm"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, p.Types.Imports())
+	_, err = l.LoadPackageWithCustomTypeSizes("./testdata/nonexistent", types.SizesFor("gc", "386"))
+	assert.ErrorIs(t, err, ErrNotFound)
+}
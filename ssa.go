@@ -0,0 +1,33 @@
+package forklift
+
+import (
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
+)
+
+// GenerateSSA returns the SSA form of p, built with mode.
+// The dependencies of p are created from their types, without function bodies.
+// It requires syntax, types, and types info.
+func GenerateSSA(p *packages.Package, mode ssa.BuilderMode) (*ssa.Package, error) {
+	if err := need(p, packages.NeedSyntax|packages.NeedTypes|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	prog := ssa.NewProgram(p.Fset, mode)
+	created := map[*types.Package]bool{}
+	var create func([]*types.Package)
+	create = func(ps []*types.Package) {
+		for _, pkg := range ps {
+			if !created[pkg] {
+				created[pkg] = true
+				prog.CreatePackage(pkg, nil, nil, true)
+				create(pkg.Imports())
+			}
+		}
+	}
+	create(p.Types.Imports())
+	s := prog.CreatePackage(p.Types, p.Syntax, p.TypesInfo, false)
+	s.Build()
+	return s, nil
+}
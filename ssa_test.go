@@ -0,0 +1,23 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/ssa"
+)
+
+func TestGenerateSSA(t *testing.T) {
+	t.Parallel()
+	p := loadTestdata(t, "calls/caller")
+	s, err := GenerateSSA(p, ssa.SanityCheckFunctions)
+	require.NoError(t, err)
+	assert.Same(t, p.Types, s.Pkg)
+	f := s.Func("A")
+	require.NotNil(t, f)
+	assert.NotEmpty(t, f.Blocks)
+	assert.NotNil(t, s.Prog.ImportedPackage("github.com/willfaught/forklift/testdata/calls/callee"))
+	_, err = GenerateSSA(nil, 0)
+	assert.Equal(t, ErrNotFound, err)
+}
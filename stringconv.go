@@ -0,0 +1,71 @@
+package forklift
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// StringConv is a conversion between []byte and string.
+type StringConv struct {
+	// Direction is "bytes-to-string" or "string-to-bytes".
+	Direction string
+
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+
+	// InLoop is whether the conversion is in the body of a for statement.
+	InLoop bool
+}
+
+func isBytes(t types.Type) bool {
+	s, ok := t.Underlying().(*types.Slice)
+	if !ok {
+		return false
+	}
+	b, ok := s.Elem().Underlying().(*types.Basic)
+	return ok && b.Kind() == types.Byte
+}
+
+func isString(t types.Type) bool {
+	b, ok := t.Underlying().(*types.Basic)
+	return ok && b.Info()&types.IsString != 0
+}
+
+// FindStringConversionCosts returns the conversions between []byte and string in p,
+// which usually allocate.
+// It requires syntax and types info.
+func FindStringConversionCosts(p *packages.Package) ([]StringConv, error) {
+	if err := need(p, packages.NeedSyntax|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	var convs []StringConv
+	for _, f := range p.Syntax {
+		inspectLoops(f, func(n ast.Node, inLoop bool) {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) != 1 || !p.TypesInfo.Types[call.Fun].IsType() {
+				return
+			}
+			to, from := p.TypesInfo.TypeOf(call.Fun), p.TypesInfo.TypeOf(call.Args[0])
+			if to == nil || from == nil {
+				return
+			}
+			var direction string
+			switch {
+			case isString(to) && isBytes(from):
+				direction = "bytes-to-string"
+			case isBytes(to) && isString(from):
+				direction = "string-to-bytes"
+			default:
+				return
+			}
+			file, line := position(p, call.Pos())
+			convs = append(convs, StringConv{Direction: direction, File: file, Line: line, InLoop: inLoop})
+		})
+	}
+	return convs, nil
+}
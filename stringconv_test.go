@@ -0,0 +1,21 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindStringConversionCosts(t *testing.T) {
+	t.Parallel()
+	convs, err := FindStringConversionCosts(loadTestdata(t, "stringconv"))
+	require.NoError(t, err)
+	for i := range convs {
+		convs[i].File = ""
+	}
+	assert.Equal(t, []StringConv{
+		{Direction: "bytes-to-string", Line: 6},
+		{Direction: "string-to-bytes", Line: 8, InLoop: true},
+	}, convs)
+}
@@ -0,0 +1,101 @@
+package forklift
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// GenerateInterfaceStub returns gofmt-formatted Go source for the package pkgName
+// that declares a struct type named stubTypeName whose pointer type implements the interface in p named ifaceName.
+// The methods return zero values.
+// If pkgName is the name of p, the source is for p; otherwise, it imports p.
+// It returns [ErrTypeNotFound] if the interface is not found.
+// It requires types.
+func GenerateInterfaceStub(p *packages.Package, ifaceName, stubTypeName, pkgName string) ([]byte, error) {
+	if err := need(p, packages.NeedTypes); err != nil {
+		return nil, err
+	}
+	i, err := lookupInterface(p, ifaceName)
+	if err != nil {
+		return nil, err
+	}
+	if named, ok := p.Types.Scope().Lookup(ifaceName).Type().(*types.Named); ok && named.TypeParams().Len() > 0 {
+		return nil, fmt.Errorf("%s is generic", ifaceName)
+	}
+	if !i.IsMethodSet() {
+		return nil, fmt.Errorf("%s is a constraint", ifaceName)
+	}
+	imports := map[string]string{}
+	qualifier := func(pkg *types.Package) string {
+		if pkg == p.Types && pkgName == p.Name {
+			return ""
+		}
+		imports[pkg.Path()] = pkg.Name()
+		return pkg.Name()
+	}
+	iface := ifaceName
+	if q := qualifier(p.Types); q != "" {
+		iface = q + "." + ifaceName
+	}
+	var methods bytes.Buffer
+	for j := 0; j < i.NumMethods(); j++ {
+		m := i.Method(j)
+		if !m.Exported() && qualifier(p.Types) != "" {
+			return nil, fmt.Errorf("%s has unexported method %s", ifaceName, m.Name())
+		}
+		sig := m.Type().(*types.Signature)
+		params := make([]string, sig.Params().Len())
+		for k := range params {
+			t := sig.Params().At(k).Type()
+			if sig.Variadic() && k == len(params)-1 {
+				params[k] = "..." + types.TypeString(t.(*types.Slice).Elem(), qualifier)
+			} else {
+				params[k] = types.TypeString(t, qualifier)
+			}
+		}
+		results := make([]string, sig.Results().Len())
+		named := true
+		for k := range results {
+			if name := sig.Results().At(k).Name(); name == "" || name == "_" {
+				named = false
+			}
+		}
+		for k := range results {
+			name := fmt.Sprintf("r%d", k)
+			if named {
+				name = sig.Results().At(k).Name()
+			}
+			results[k] = name + " " + types.TypeString(sig.Results().At(k).Type(), qualifier)
+		}
+		fmt.Fprintf(&methods, "\n// %s implements %s.\nfunc (*%s) %s(%s) ", m.Name(), iface, stubTypeName, m.Name(), strings.Join(params, ", "))
+		if len(results) == 0 {
+			methods.WriteString("{}\n")
+		} else {
+			fmt.Fprintf(&methods, "(%s) {\n\treturn\n}\n", strings.Join(results, ", "))
+		}
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "package %s\n", pkgName)
+	if len(imports) > 0 {
+		paths := make([]string, 0, len(imports))
+		for path := range imports {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		b.WriteString("\nimport (\n")
+		for _, path := range paths {
+			fmt.Fprintf(&b, "\t%q\n", path)
+		}
+		b.WriteString(")\n")
+	}
+	fmt.Fprintf(&b, "\n// %s is a stub implementation of %s.\ntype %s struct{}\n", stubTypeName, iface, stubTypeName)
+	fmt.Fprintf(&b, "\nvar _ %s = (*%s)(nil)\n", iface, stubTypeName)
+	b.Write(methods.Bytes())
+	return format.Source(b.Bytes())
+}
@@ -0,0 +1,67 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateInterfaceStub(t *testing.T) {
+	t.Parallel()
+	p := loadTestdata(t, "interfaces")
+	src, err := GenerateInterfaceStub(p, "big", "stub", "interfaces")
+	require.NoError(t, err)
+	assert.Equal(t, `package interfaces
+
+// stub is a stub implementation of big.
+type stub struct{}
+
+var _ big = (*stub)(nil)
+
+// Close implements big.
+func (*stub) Close() (r0 error) {
+	return
+}
+
+// Read implements big.
+func (*stub) Read([]byte) (n int, err error) {
+	return
+}
+
+// Write implements big.
+func (*stub) Write([]byte) (r0 int, r1 error) {
+	return
+}
+
+// flush implements big.
+func (*stub) flush() {}
+`, string(src))
+	src, err = GenerateInterfaceStub(p, "ReadCloser", "Stub", "mocks")
+	require.NoError(t, err)
+	assert.Equal(t, `package mocks
+
+import (
+	"github.com/willfaught/forklift/testdata/interfaces"
+)
+
+// Stub is a stub implementation of interfaces.ReadCloser.
+type Stub struct{}
+
+var _ interfaces.ReadCloser = (*Stub)(nil)
+
+// Close implements interfaces.ReadCloser.
+func (*Stub) Close() (r0 error) {
+	return
+}
+
+// Read implements interfaces.ReadCloser.
+func (*Stub) Read([]byte) (n int, err error) {
+	return
+}
+`, string(src))
+	_, err = GenerateInterfaceStub(p, "big", "Stub", "mocks")
+	assert.EqualError(t, err, "big has unexported method flush")
+	_, err = GenerateInterfaceStub(p, "missing", "Stub", "mocks")
+	assert.Equal(t, ErrTypeNotFound, err)
+}
@@ -0,0 +1,77 @@
+package forklift
+
+import (
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// SymbolCount is the number of package-level declarations and methods by kind.
+type SymbolCount struct {
+	// ExportedTypes is the number of exported types.
+	ExportedTypes int
+
+	// UnexportedTypes is the number of unexported types.
+	UnexportedTypes int
+
+	// ExportedFuncs is the number of exported functions.
+	ExportedFuncs int
+
+	// UnexportedFuncs is the number of unexported functions.
+	UnexportedFuncs int
+
+	// ExportedMethods is the number of exported methods.
+	ExportedMethods int
+
+	// UnexportedMethods is the number of unexported methods.
+	UnexportedMethods int
+
+	// ExportedVars is the number of exported variables.
+	ExportedVars int
+
+	// UnexportedVars is the number of unexported variables.
+	UnexportedVars int
+
+	// ExportedConsts is the number of exported constants.
+	ExportedConsts int
+
+	// UnexportedConsts is the number of unexported constants.
+	UnexportedConsts int
+}
+
+// count increments exported if obj is exported, and unexported otherwise.
+func count(obj types.Object, exported, unexported *int) {
+	if obj.Exported() {
+		*exported++
+	} else {
+		*unexported++
+	}
+}
+
+// CountSymbols returns the number of package-level declarations and methods in p by kind.
+// It requires types.
+func CountSymbols(p *packages.Package) (*SymbolCount, error) {
+	if err := need(p, packages.NeedTypes); err != nil {
+		return nil, err
+	}
+	var c SymbolCount
+	scope := p.Types.Scope()
+	for _, name := range scope.Names() {
+		switch obj := scope.Lookup(name).(type) {
+		case *types.TypeName:
+			count(obj, &c.ExportedTypes, &c.UnexportedTypes)
+			if named, ok := obj.Type().(*types.Named); ok && !obj.IsAlias() {
+				for i := 0; i < named.NumMethods(); i++ {
+					count(named.Method(i), &c.ExportedMethods, &c.UnexportedMethods)
+				}
+			}
+		case *types.Func:
+			count(obj, &c.ExportedFuncs, &c.UnexportedFuncs)
+		case *types.Var:
+			count(obj, &c.ExportedVars, &c.UnexportedVars)
+		case *types.Const:
+			count(obj, &c.ExportedConsts, &c.UnexportedConsts)
+		}
+	}
+	return &c, nil
+}
@@ -0,0 +1,26 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountSymbols(t *testing.T) {
+	t.Parallel()
+	c, err := CountSymbols(loadTestdata(t, "symbols"))
+	require.NoError(t, err)
+	assert.Equal(t, &SymbolCount{
+		ExportedTypes:     1,
+		UnexportedTypes:   1,
+		ExportedFuncs:     1,
+		UnexportedFuncs:   2,
+		ExportedMethods:   1,
+		UnexportedMethods: 1,
+		ExportedVars:      1,
+		UnexportedVars:    1,
+		ExportedConsts:    1,
+		UnexportedConsts:  2,
+	}, c)
+}
@@ -0,0 +1,134 @@
+package forklift
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// ErrNotLoaded means the package information needed was not loaded.
+var ErrNotLoaded = fmt.Errorf("package information not loaded")
+
+// need returns [ErrNotLoaded] if the information for mode is missing from p.
+func need(p *packages.Package, mode packages.LoadMode) error {
+	switch {
+	case p == nil:
+		return ErrNotFound
+	case mode&packages.NeedFiles != 0 && p.GoFiles == nil:
+		return ErrNotLoaded
+	case mode&packages.NeedSyntax != 0 && (p.Fset == nil || p.Syntax == nil):
+		return ErrNotLoaded
+	case mode&packages.NeedTypes != 0 && p.Types == nil:
+		return ErrNotLoaded
+	case mode&packages.NeedTypesInfo != 0 && p.TypesInfo == nil:
+		return ErrNotLoaded
+	case mode&packages.NeedTypesSizes != 0 && p.TypesSizes == nil:
+		return ErrNotLoaded
+	}
+	return nil
+}
+
+// position returns the file name and line of pos.
+func position(p *packages.Package, pos token.Pos) (string, int) {
+	position := p.Fset.Position(pos)
+	return position.Filename, position.Line
+}
+
+// funcName returns the name of f, qualified by its receiver type name if it is a method.
+func funcName(f *types.Func) string {
+	sig, ok := f.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil {
+		return f.Name()
+	}
+	t := sig.Recv().Type()
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	if named, ok := t.(*types.Named); ok {
+		return named.Obj().Name() + "." + f.Name()
+	}
+	return f.Name()
+}
+
+// declName returns the name of d, qualified by its receiver type name if it is a method.
+func declName(d *ast.FuncDecl) string {
+	if d.Recv == nil || len(d.Recv.List) == 0 {
+		return d.Name.Name
+	}
+	t := d.Recv.List[0].Type
+	for {
+		switch x := t.(type) {
+		case *ast.ParenExpr:
+			t = x.X
+		case *ast.StarExpr:
+			t = x.X
+		case *ast.IndexExpr:
+			t = x.X
+		case *ast.IndexListExpr:
+			t = x.X
+		case *ast.Ident:
+			return x.Name + "." + d.Name.Name
+		default:
+			return d.Name.Name
+		}
+	}
+}
+
+// inspect calls visit for each node in the syntax of p
+// with the name of the enclosing function declaration, if any.
+// The children of n are skipped if visit returns false.
+func inspect(p *packages.Package, visit func(n ast.Node, fn string) bool) {
+	for _, f := range p.Syntax {
+		for _, d := range f.Decls {
+			var fn string
+			if d, ok := d.(*ast.FuncDecl); ok {
+				fn = declName(d)
+			}
+			ast.Inspect(d, func(n ast.Node) bool {
+				if n == nil {
+					return true
+				}
+				return visit(n, fn)
+			})
+		}
+	}
+}
+
+var errorType = types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+
+// isError returns whether t implements error.
+func isError(t types.Type) bool {
+	return t != nil && types.Implements(t, errorType)
+}
+
+// inspectLoops calls visit for each node in f with whether it is in the body of a for statement
+// in the same function.
+func inspectLoops(f *ast.File, visit func(n ast.Node, inLoop bool)) {
+	bodies := map[*ast.BlockStmt]bool{}
+	var stack []bool
+	ast.Inspect(f, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		inLoop := len(stack) > 0 && stack[len(stack)-1]
+		visit(n, inLoop)
+		switch n := n.(type) {
+		case *ast.ForStmt:
+			bodies[n.Body] = true
+		case *ast.RangeStmt:
+			bodies[n.Body] = true
+		case *ast.BlockStmt:
+			if bodies[n] {
+				inLoop = true
+			}
+		case *ast.FuncLit:
+			inLoop = false
+		}
+		stack = append(stack, inLoop)
+		return true
+	})
+}
@@ -0,0 +1,68 @@
+package forklift
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// syscallPackages is the import paths of the system call packages.
+var syscallPackages = map[string]bool{
+	"syscall":                  true,
+	"golang.org/x/sys/unix":    true,
+	"golang.org/x/sys/windows": true,
+}
+
+// SyscallUse is a use of a system call package.
+type SyscallUse struct {
+	// ImportPath is the package import path.
+	ImportPath string
+
+	// FuncName is the called function name, or the system call number constant name.
+	// Methods are qualified by their receiver type name.
+	FuncName string
+
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+}
+
+// FindSyscallUsage returns the calls of functions and methods in p declared in the syscall,
+// golang.org/x/sys/unix, and golang.org/x/sys/windows packages,
+// and the references to their system call number constants, like SYS_GETPID.
+// Calls of function values are excluded.
+// It requires syntax and types info.
+func FindSyscallUsage(p *packages.Package) ([]SyscallUse, error) {
+	if err := need(p, packages.NeedSyntax|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	var uses []SyscallUse
+	for _, f := range p.Syntax {
+		ast.Inspect(f, func(n ast.Node) bool {
+			var obj types.Object
+			var name string
+			switch n := n.(type) {
+			case *ast.CallExpr:
+				if fn, ok := typeutil.Callee(p.TypesInfo, n).(*types.Func); ok {
+					obj, name = fn, funcName(fn)
+				}
+			case *ast.Ident:
+				if c, ok := p.TypesInfo.Uses[n].(*types.Const); ok && strings.HasPrefix(c.Name(), "SYS_") {
+					obj, name = c, c.Name()
+				}
+			}
+			if obj == nil || obj.Pkg() == nil || !syscallPackages[obj.Pkg().Path()] {
+				return true
+			}
+			file, line := position(p, n.Pos())
+			uses = append(uses, SyscallUse{ImportPath: obj.Pkg().Path(), FuncName: name, File: file, Line: line})
+			return true
+		})
+	}
+	return uses, nil
+}
@@ -0,0 +1,23 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindSyscallUsage(t *testing.T) {
+	t.Parallel()
+	uses, err := FindSyscallUsage(loadTestdata(t, "syscalls"))
+	require.NoError(t, err)
+	for i := range uses {
+		uses[i].File = ""
+	}
+	assert.Equal(t, []SyscallUse{
+		{ImportPath: "syscall", FuncName: "RawSyscall", Line: 9},
+		{ImportPath: "syscall", FuncName: "SYS_GETPID", Line: 9},
+		{ImportPath: "syscall", FuncName: "Getpid", Line: 10},
+		{ImportPath: "syscall", FuncName: "Timespec.Nano", Line: 18},
+	}, uses)
+}
@@ -0,0 +1,141 @@
+package forklift
+
+import (
+	"fmt"
+	"go/types"
+	"reflect"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// SplitTagValue returns the name component and options of a struct tag value,
+// like "name" and ["omitempty"] for "name,omitempty".
+func SplitTagValue(value string) (name string, options []string) {
+	name, rest, found := strings.Cut(value, ",")
+	if found {
+		options = strings.Split(rest, ",")
+	}
+	return name, options
+}
+
+// extractTags returns the name components of the struct tag values for key
+// of the fields of the struct types in p by type name and field name.
+func extractTags(p *packages.Package, key string) (map[string]map[string]string, error) {
+	if err := need(p, packages.NeedTypes); err != nil {
+		return nil, err
+	}
+	tags := map[string]map[string]string{}
+	scope := p.Types.Scope()
+	for _, name := range scope.Names() {
+		t, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || t.IsAlias() {
+			continue
+		}
+		s, ok := t.Type().Underlying().(*types.Struct)
+		if !ok {
+			continue
+		}
+		for i := 0; i < s.NumFields(); i++ {
+			value, ok := reflect.StructTag(s.Tag(i)).Lookup(key)
+			if !ok {
+				continue
+			}
+			if tags[name] == nil {
+				tags[name] = map[string]string{}
+			}
+			tags[name][s.Field(i).Name()], _ = SplitTagValue(value)
+		}
+	}
+	return tags, nil
+}
+
+// ExtractXMLTags returns the name components of the xml struct tag values
+// of the fields of the struct types in p by type name and field name,
+// like "name" for `xml:"name,omitempty"`, or "" if the name is omitted.
+// It requires types.
+func ExtractXMLTags(p *packages.Package) (map[string]map[string]string, error) {
+	return extractTags(p, "xml")
+}
+
+// ExtractYAMLTags returns the name components of the yaml struct tag values
+// of the fields of the struct types in p by type name and field name,
+// like "name" for `yaml:"name,omitempty"`, or "" if the name is omitted.
+// It requires types.
+func ExtractYAMLTags(p *packages.Package) (map[string]map[string]string, error) {
+	return extractTags(p, "yaml")
+}
+
+// CompatIssue is a struct field serialization problem.
+type CompatIssue struct {
+	// FieldName is the field name.
+	FieldName string
+
+	// Format is "json" or "xml".
+	Format string
+
+	// Issue is the problem description.
+	Issue string
+}
+
+// CheckSerializationCompatibility returns the problems serializing the struct type in p
+// named typeName with encoding/json and encoding/xml:
+// unexported fields that are not serialized,
+// fields whose serialized names collide after applying struct tags,
+// and fields omitted with "-" in one format but not the other.
+// It returns [ErrTypeNotFound] if the type is not found.
+// It requires types.
+func CheckSerializationCompatibility(p *packages.Package, typeName string) ([]CompatIssue, error) {
+	if err := need(p, packages.NeedTypes); err != nil {
+		return nil, err
+	}
+	t, err := lookupType(p, typeName)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := t.Type().Underlying().(*types.Struct)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a struct", typeName)
+	}
+	formats := []string{"json", "xml"}
+	names := map[string]map[string]string{}
+	for _, format := range formats {
+		names[format] = map[string]string{}
+	}
+	var issues []CompatIssue
+	for i := 0; i < s.NumFields(); i++ {
+		f := s.Field(i)
+		if !f.Exported() {
+			if !f.Embedded() {
+				issues = append(issues, CompatIssue{FieldName: f.Name(), Format: "json", Issue: "unexported field is not serialized"})
+			}
+			continue
+		}
+		tag := reflect.StructTag(s.Tag(i))
+		omitted := map[string]bool{}
+		for _, format := range formats {
+			value, _ := tag.Lookup(format)
+			name, _ := SplitTagValue(value)
+			if name == "-" {
+				omitted[format] = true
+				continue
+			}
+			if name == "" {
+				name = f.Name()
+			}
+			if other, ok := names[format][name]; ok {
+				issues = append(issues, CompatIssue{FieldName: f.Name(), Format: format, Issue: fmt.Sprintf("name %q collides with field %s", name, other)})
+			} else {
+				names[format][name] = f.Name()
+			}
+		}
+		for _, format := range formats {
+			for _, other := range formats {
+				if omitted[other] && !omitted[format] {
+					issues = append(issues, CompatIssue{FieldName: f.Name(), Format: format, Issue: fmt.Sprintf("field is omitted from %s but not %s", other, format)})
+				}
+			}
+		}
+	}
+	return issues, nil
+}
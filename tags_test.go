@@ -0,0 +1,67 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitTagValue(t *testing.T) {
+	t.Parallel()
+	for _, test := range []struct {
+		value   string
+		name    string
+		options []string
+	}{
+		{},
+		{value: "-", name: "-"},
+		{value: "id", name: "id"},
+		{value: "id,attr", name: "id", options: []string{"attr"}},
+		{value: ",chardata", options: []string{"chardata"}},
+		{value: "items,flow,omitempty", name: "items", options: []string{"flow", "omitempty"}},
+	} {
+		test := test
+		t.Run(test.value, func(t *testing.T) {
+			t.Parallel()
+			name, options := SplitTagValue(test.value)
+			assert.Equal(t, test.name, name)
+			assert.Equal(t, test.options, options)
+		})
+	}
+}
+
+func TestExtractXMLTags(t *testing.T) {
+	t.Parallel()
+	tags, err := ExtractXMLTags(loadTestdata(t, "tags"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"Doc":   {"ID": "id", "Body": ""},
+		"Mixed": {"Name": "name", "Title": "title", "Secret": "secret", "Hidden": "-"},
+	}, tags)
+}
+
+func TestExtractYAMLTags(t *testing.T) {
+	t.Parallel()
+	tags, err := ExtractYAMLTags(loadTestdata(t, "tags"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{"Doc": {"ID": "id", "Items": "items"}}, tags)
+}
+
+func TestCheckSerializationCompatibility(t *testing.T) {
+	t.Parallel()
+	p := loadTestdata(t, "tags")
+	issues, err := CheckSerializationCompatibility(p, "Mixed")
+	require.NoError(t, err)
+	assert.Equal(t, []CompatIssue{
+		{FieldName: "Title", Format: "json", Issue: `name "name" collides with field Name`},
+		{FieldName: "Secret", Format: "xml", Issue: "field is omitted from json but not xml"},
+		{FieldName: "Hidden", Format: "json", Issue: "field is omitted from xml but not json"},
+		{FieldName: "count", Format: "json", Issue: "unexported field is not serialized"},
+	}, issues)
+	issues, err = CheckSerializationCompatibility(p, "plain")
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+	_, err = CheckSerializationCompatibility(p, "Missing")
+	assert.Equal(t, ErrTypeNotFound, err)
+}
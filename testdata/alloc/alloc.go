@@ -0,0 +1,13 @@
+package alloc
+
+type T struct{}
+
+func f(n int) []int {
+	s := make([]int, 0, n)
+	for i := 0; i < n; i++ {
+		s = append(s, i)
+		_ = func() *T { return new(T) }
+	}
+	_ = &T{}
+	return s
+}
@@ -0,0 +1,7 @@
+package asm
+
+func Add(a, b int64) int64
+
+func Sub(a, b int32) int32
+
+func Neg(a int64) int64
@@ -0,0 +1,19 @@
+#include "textflag.h"
+
+TEXT ·Add(SB), NOSPLIT, $0-24
+	MOVQ a+0(FP), AX
+	ADDQ b+8(FP), AX
+	MOVQ AX, ret+16(FP)
+	RET
+
+TEXT ·Sub(SB), NOSPLIT, $0-24
+	MOVL a+0(FP), AX
+	SUBL b+8(FP), AX
+	MOVL AX, ret+16(FP)
+	RET
+
+TEXT ·Neg(SB), NOSPLIT, $0-16
+	MOVL a+0(FP), AX
+	NEGQ AX
+	MOVQ AX, ret+8(FP)
+	RET
@@ -0,0 +1,14 @@
+package assertions
+
+import "fmt"
+
+func f(x any) {
+	_ = x.(int)
+	s, ok := x.(string)
+	_, _ = s, ok
+	var st, ok2 = x.(fmt.Stringer)
+	_, _ = st, ok2
+	switch x.(type) {
+	}
+	fmt.Println(x.(error))
+}
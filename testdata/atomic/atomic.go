@@ -0,0 +1,14 @@
+package atomic
+
+import "sync/atomic"
+
+var (
+	n int64
+	m atomic.Int64
+)
+
+func f() {
+	atomic.AddInt64(&n, 1)
+	m.Add(1)
+	_ = m.Load()
+}
@@ -0,0 +1,13 @@
+package callee
+
+type T struct{}
+
+func (T) M() {}
+
+func (*T) P() int { return 0 }
+
+type I interface{ N() }
+
+func F() T { return T{} }
+
+func G[X any](x X) X { return x }
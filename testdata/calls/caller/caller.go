@@ -0,0 +1,24 @@
+package caller
+
+import (
+	"fmt"
+
+	"github.com/willfaught/forklift/testdata/calls/callee"
+)
+
+var v = callee.F()
+
+func A(i callee.I) {
+	callee.F().M()
+	i.N()
+	fmt.Println(callee.G(1))
+}
+
+type S struct{ callee.T }
+
+func (s *S) B() {
+	s.P()
+	f := callee.F
+	f()
+	_ = callee.T{}
+}
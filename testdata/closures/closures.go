@@ -0,0 +1,24 @@
+package closures
+
+import "os"
+
+var global int
+
+func F(a, b int) func() int {
+	c := 3
+	simple := func() int { return a }
+	_ = simple
+	return func() int {
+		d := a + b + c + global
+		return func() int {
+			return a + d
+		}()
+	}
+}
+
+func G(a int) func() []string {
+	return func() []string {
+		_ = a
+		return os.Args
+	}
+}
@@ -0,0 +1,15 @@
+package compare
+
+import "strings"
+
+func Kept() {}
+
+func Changed(string) {}
+
+func Added() {}
+
+type T struct{}
+
+func (T) M(int) {}
+
+var _ = strings.TrimSpace("")
@@ -0,0 +1,15 @@
+package compare
+
+import "fmt"
+
+func Kept() {}
+
+func Changed(int) {}
+
+func Removed() {}
+
+type T struct{}
+
+func (T) M() {}
+
+var _ = fmt.Sprint()
@@ -0,0 +1,24 @@
+package complexity
+
+func simple() {}
+
+func complex(xs []int, c chan int) int {
+	n := 0
+	for _, x := range xs {
+		if x > 0 && x < 10 || x == 100 {
+			n++
+		}
+	}
+	for i := 0; i < n; i++ {
+		switch i {
+		case 1:
+		case 2:
+		default:
+		}
+	}
+	select {
+	case <-c:
+	default:
+	}
+	return n
+}
@@ -0,0 +1,7 @@
+//go:build unix
+
+package conflicts
+
+func Close() {}
+
+func helper() {}
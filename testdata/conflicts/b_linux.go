@@ -0,0 +1,5 @@
+package conflicts
+
+func Close() {}
+
+func helper() {}
@@ -0,0 +1,5 @@
+//go:build cgo
+
+package conflicts
+
+var Mode = 1
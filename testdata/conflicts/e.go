@@ -0,0 +1,5 @@
+//go:build go1.21
+
+package conflicts
+
+const Version = 21
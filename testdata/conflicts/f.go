@@ -0,0 +1,5 @@
+//go:build !go1.21 || netgo
+
+package conflicts
+
+const Version = 20
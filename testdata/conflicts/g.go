@@ -0,0 +1,3 @@
+package conflicts
+
+type T struct{}
@@ -0,0 +1,3 @@
+package conflicts
+
+func (T) String() string { return "plan9" }
@@ -0,0 +1,4 @@
+// +build darwin freebsd
+// +build !cgo
+
+package constraints
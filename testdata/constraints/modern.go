@@ -0,0 +1,10 @@
+// Copyright notice.
+
+//go:build linux && (amd64 || arm64)
+// +build linux
+// +build amd64 arm64
+
+// Package constraints has build constraints.
+package constraints
+
+//go:build ignored
@@ -0,0 +1,3 @@
+package constraints
+
+// +build ignored
@@ -0,0 +1,17 @@
+package constructors
+
+import "io"
+
+type Service struct{}
+
+func New() *Service { return nil }
+
+func NewService(w io.Writer, db *Service, _ int, opts ...string) (*Service, error) { return nil, nil }
+
+func NewReader(io.Reader) io.Reader { return nil }
+
+func Newest() {}
+
+func newService() {}
+
+func (Service) NewChild() {}
@@ -0,0 +1,9 @@
+package a
+
+import (
+	"fmt"
+
+	"github.com/willfaught/forklift/testdata/coupling/b"
+)
+
+var _ = fmt.Sprint(b.C{})
@@ -0,0 +1,10 @@
+package b
+
+// B is abstract.
+type B interface{}
+
+// C is concrete.
+type C struct{}
+
+// D is concrete.
+type D int
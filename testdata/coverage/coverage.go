@@ -0,0 +1,16 @@
+package coverage
+
+func Abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+type T struct{}
+
+func (T) M() {}
+
+func Uncovered() int {
+	return 0
+}
@@ -0,0 +1,48 @@
+package ctxprop
+
+import (
+	"context"
+	"time"
+)
+
+func use(ctx context.Context, n int) {}
+
+type S struct{}
+
+func (S) Do(ctx context.Context) {}
+
+func Good(ctx context.Context) {
+	use(ctx, 1)
+	ctx2, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	use(ctx2, 2)
+	S{}.Do(context.WithValue(ctx, "k", "v"))
+}
+
+func Bad(ctx context.Context, f func(context.Context)) {
+	use(context.Background(), 1)
+	S{}.Do(context.TODO())
+	f(nil)
+}
+
+func NoContext() {
+	use(context.Background(), 1)
+}
+
+func First(ctx context.Context, n int) {}
+
+func Second(n int, ctx context.Context) {}
+
+func second(n int, ctx context.Context) {}
+
+func Pointer(ctx *context.Context) {}
+
+func (S) Third(a, b int, ctx context.Context) {}
+
+type Holder struct {
+	ctx context.Context
+}
+
+type holder struct {
+	context.Context
+}
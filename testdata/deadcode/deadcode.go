@@ -0,0 +1,27 @@
+package deadcode
+
+type stringer interface {
+	string() string
+}
+
+type used struct{}
+
+func (used) string() string { return "" }
+
+func (used) unused() {}
+
+type unused struct{}
+
+const unusedConst = 1
+
+var unusedVar int
+
+var _ = helper
+
+func helper() {}
+
+func unusedFunc() {}
+
+func init() {}
+
+func Exported() stringer { return used{} }
@@ -0,0 +1,17 @@
+package deferred
+
+import "sync"
+
+type T struct {
+	mu sync.Mutex
+}
+
+func (t *T) f() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	defer func() {}()
+}
+
+func g() {
+	defer println()
+}
@@ -0,0 +1,69 @@
+package embedding
+
+import (
+	"io"
+	"strings"
+)
+
+type A struct{}
+
+func (A) Close() error { return nil }
+
+func (*A) Name() string { return "" }
+
+type B struct{}
+
+func (B) Close() error { return nil }
+
+func (B) Name() string { return "" }
+
+type Both struct {
+	A
+	*B
+	io.Writer
+	*strings.Builder
+}
+
+func (Both) Name() string { return "" }
+
+type inner struct{}
+
+func (inner) Hidden() {}
+
+type Outer struct {
+	inner
+	*A
+	error
+}
+
+type outer struct {
+	inner
+}
+
+type C struct{}
+
+func (C) M() {}
+
+type D struct{ C }
+
+type E struct{}
+
+func (E) M() {}
+
+type G struct{ C }
+
+type Shallow struct {
+	E
+	D
+}
+
+type Deep struct {
+	D
+	G
+}
+
+type Fields struct {
+	A
+	*B
+	Close, Name int
+}
@@ -0,0 +1,14 @@
+package embedfiles
+
+import "embed"
+
+//go:embed hello.txt
+var hello string
+
+//go:embed static/*.txt "quoted name.txt"
+var files embed.FS
+
+var (
+	//go:embed static
+	static embed.FS
+)
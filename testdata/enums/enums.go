@@ -0,0 +1,30 @@
+package enums
+
+type Color int
+
+const (
+	Red Color = iota
+	Green
+	Blue
+)
+
+const (
+	_  = iota
+	KB = 1 << (10 * iota)
+	MB
+)
+
+const (
+	A = 1
+	B = 2
+)
+
+const Single = iota
+
+type Level uint8
+
+const (
+	Debug Level = iota + 1
+	Info
+	Warn = Info + 10
+)
@@ -0,0 +1,17 @@
+package errcompare
+
+import (
+	"errors"
+	"io"
+)
+
+func f(r io.Reader) bool {
+	_, err := r.Read(nil)
+	if err != nil && err != io.EOF {
+		return false
+	}
+	if errors.Is(err, io.EOF) {
+		return true
+	}
+	return io.ErrClosedPipe == err
+}
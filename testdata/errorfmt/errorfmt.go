@@ -0,0 +1,17 @@
+package errorfmt
+
+import (
+	"errors"
+	"fmt"
+)
+
+var errBase = errors.New("base")
+
+func f(name string) []error {
+	return []error{
+		fmt.Errorf("cannot open %s: %v", name, errBase),
+		fmt.Errorf("cannot open %s: %w", name, errBase),
+		fmt.Errorf("%[2]v %[1]s", name, errBase),
+		fmt.Errorf("100%% %*d %v", 3, 4, name),
+	}
+}
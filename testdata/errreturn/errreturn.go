@@ -0,0 +1,42 @@
+package errreturn
+
+import (
+	"errors"
+	"os"
+	"strconv"
+)
+
+func Remove(name string) {
+	_ = os.Remove(name)
+}
+
+func Atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+func Checked(name string) error {
+	return os.Remove(name)
+}
+
+func Pure(a, b int) int { return a + b }
+
+func quiet(name string) { _ = os.Remove(name) }
+
+type Scanner struct{ err error }
+
+func (s *Scanner) Scan() bool {
+	s.err = errors.New("done")
+	_, s.err = strconv.Atoi("x")
+	return false
+}
+
+func (s *Scanner) Err() error { return s.err }
+
+type Writer struct{}
+
+func (Writer) Flush() { _ = os.Remove("") }
+
+type hidden struct{}
+
+func (hidden) Flush() { _ = os.Remove("") }
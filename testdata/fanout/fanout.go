@@ -0,0 +1,31 @@
+package fanout
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+type T struct{}
+
+func (T) M() {}
+
+func helper() {}
+
+func Generic[E any](e E) E { return e }
+
+func F(err error) error {
+	helper()
+	helper()
+	T{}.M()
+	f := strings.TrimSpace
+	fmt.Println(f(err.Error()), len("x"))
+	Generic(1)
+	Generic("s")
+	_, _ = packages.Load(nil)
+	return errors.New("x")
+}
+
+func Empty() {}
@@ -0,0 +1,11 @@
+package finalizer
+
+import "runtime"
+
+type file struct{}
+
+func open() *file {
+	f := &file{}
+	runtime.SetFinalizer(f, func(*file) {})
+	return f
+}
@@ -0,0 +1,24 @@
+package gc
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+type T struct{}
+
+func (*T) Reset() {
+	runtime.GC()
+	runtime.GC()
+}
+
+func Free() {
+	func() {
+		runtime.GC()
+	}()
+	debug.FreeOSMemory()
+}
+
+func Other() {
+	runtime.Gosched()
+}
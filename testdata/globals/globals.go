@@ -0,0 +1,15 @@
+package globals
+
+import "io"
+
+var Count int
+
+var cache = map[string]int{}
+
+var (
+	names  []string
+	Writer io.Writer
+	ptr    *int
+)
+
+const limit = 10
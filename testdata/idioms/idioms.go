@@ -0,0 +1,53 @@
+package idioms
+
+import (
+	"fmt"
+	"os"
+)
+
+func Name() {
+	e := os.Remove("")
+	fmt.Println(e)
+	err := os.Remove("")
+	fmt.Println(err)
+}
+
+func Check() error {
+	err := os.Remove("a")
+	if err != nil {
+		return err
+	}
+	err = os.Remove("b")
+	fmt.Println("removed")
+	if err != nil {
+		return err
+	}
+	if err := os.Remove("c"); err != nil {
+		return err
+	}
+	var err2 error = os.Remove("d")
+	fmt.Println()
+	if nil != err2 {
+		return err2
+	}
+	return nil
+}
+
+func Param(err error) error {
+	fmt.Println()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func Assert(x any) {
+	_ = x.(string)
+	s, ok := x.(string)
+	fmt.Println(s, ok)
+	switch x.(type) {
+	case int:
+	}
+}
+
+type T struct{ e error }
@@ -0,0 +1,25 @@
+package implementers
+
+import "database/sql/driver"
+
+type level int
+
+func (l *level) String() string { return "" }
+
+func (l *level) Set(string) error { return nil }
+
+type name string
+
+func (n name) String() string { return string(n) }
+
+func (n name) Set(string) error { return nil }
+
+type scanned struct{}
+
+func (*scanned) Scan(any) error { return nil }
+
+type both struct{}
+
+func (*both) Scan(any) error { return nil }
+
+func (both) Value() (driver.Value, error) { return nil, nil }
@@ -0,0 +1,13 @@
+package initorder
+
+var a = b + c
+
+var b = f()
+
+var c = 1
+
+func f() int {
+	return c
+}
+
+func init() {}
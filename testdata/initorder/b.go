@@ -0,0 +1,3 @@
+package initorder
+
+func init() {}
@@ -0,0 +1,30 @@
+package interfaces
+
+import "io"
+
+type Reader interface {
+	Read(p []byte) (n int, err error)
+}
+
+type ReadCloser interface {
+	io.Reader
+	Close() error
+}
+
+type big interface {
+	ReadCloser
+	Write([]byte) (int, error)
+	flush()
+}
+
+type empty interface{}
+
+type file struct{}
+
+func (*file) Read([]byte) (int, error) { return 0, nil }
+
+func (*file) Close() error { return nil }
+
+type value struct{}
+
+func (value) Read([]byte) (int, error) { return 0, nil }
@@ -0,0 +1,19 @@
+package leaks
+
+type hidden struct{}
+
+type Public struct {
+	Field  []*hidden
+	field  hidden
+	Nested map[string]func(hidden)
+}
+
+func New() *hidden { return nil }
+
+func Take(Public) {}
+
+func (Public) Method(chan hidden) {}
+
+func (Public) method(hidden) {}
+
+func internal(hidden) {}
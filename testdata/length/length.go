@@ -0,0 +1,16 @@
+package length
+
+func short() {}
+
+// long is long.
+func long() {
+	// comment
+
+	_ = 1
+}
+
+type T struct{}
+
+func (T) method() {
+	_ = 1
+}
@@ -0,0 +1,22 @@
+package linker
+
+import _ "unsafe"
+
+//go:linkname nanotime runtime.nanotime
+func nanotime() int64
+
+//go:linkname exported
+var exported int
+
+// Add adds.
+//
+//export Add
+func Add(a, b int) int { return a + b }
+
+//go:nosplit
+func fast() {}
+
+//go:cgo_import_dynamic libc_getpid getpid "libc.so.6"
+
+//go:noinline
+func slow() {}
@@ -0,0 +1,26 @@
+package literals
+
+const (
+	size = 1024
+	rate = 0.5
+)
+
+func f(n int) float64 {
+	if n > 0 {
+		n = n*3 + 1
+	}
+	if n == -1 {
+		return 2.5
+	}
+	return float64(n) / 0x10
+}
+
+const greeting = "hello, world"
+
+type config struct {
+	Name string `json:"name,omitempty"`
+}
+
+func g() []string {
+	return []string{"hi", "password123", `raw string`, "héllo", greeting}
+}
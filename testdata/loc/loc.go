@@ -0,0 +1,9 @@
+package loc
+
+// F does nothing.
+func F() {
+	/* block
+	comment */
+	_ = `raw
+string` // trailing
+}
@@ -0,0 +1,6 @@
+package loc
+
+import "testing"
+
+// TestF tests F.
+func TestF(*testing.T) {}
@@ -0,0 +1,21 @@
+package nesting
+
+func f(xs []int) {
+	for _, x := range xs {
+		if x > 0 {
+			switch x {
+			case 1:
+				if x == 1 {
+				}
+			}
+		} else if x < 0 {
+		} else if x == 0 {
+		}
+	}
+	if len(xs) > 0 {
+		func() {
+			for {
+			}
+		}()
+	}
+}
@@ -0,0 +1,21 @@
+package nilderef
+
+type T struct {
+	n int
+}
+
+func (t *T) get() int {
+	return t.n
+}
+
+func (t T) value() int {
+	return t.n
+}
+
+func f(a, b *T, c *int) int {
+	if b == nil {
+		return 0
+	}
+	d := &T{}
+	return a.n + b.n + *c + d.n + a.get() + a.value()
+}
@@ -0,0 +1,17 @@
+package params
+
+type T struct{}
+
+func (t T) method(a, b int) int {
+	return a
+}
+
+func f(x int, _ string, y func(int) int) {
+	_ = y(x)
+}
+
+func g(unused bool) {}
+
+func h(int) {}
+
+var lit = func(z int) {}
@@ -0,0 +1,16 @@
+//go:build go1.21
+
+package races
+
+func OldLoop(xs []int) {
+	for i := 0; i < len(xs); i++ {
+		go func() {
+			_ = xs[i]
+		}()
+	}
+	for _, x := range xs {
+		go func(x int) {
+			_ = x
+		}(x)
+	}
+}
@@ -0,0 +1,47 @@
+package races
+
+import (
+	"math/rand"
+	"sync"
+)
+
+var (
+	cache = map[string]int{}
+	mu    sync.Mutex
+	rng   = rand.New(rand.NewSource(1))
+)
+
+func Write() {
+	go func() {
+		cache["a"] = 1
+		delete(cache, "b")
+	}()
+	go func() {
+		mu.Lock()
+		defer mu.Unlock()
+		cache["c"] = 2
+	}()
+	local := map[string]int{}
+	go func() {
+		local["d"]++
+	}()
+}
+
+func Random() int {
+	r := rand.New(rand.NewSource(2))
+	return rng.Intn(10) + r.Intn(10) + rand.Intn(10)
+}
+
+func LockedRandom() int {
+	mu.Lock()
+	defer mu.Unlock()
+	return rng.Intn(10)
+}
+
+func Loop(xs []int) {
+	for i, x := range xs {
+		go func() {
+			_ = i + x
+		}()
+	}
+}
@@ -0,0 +1,5 @@
+package dep
+
+import "example.com/dep2"
+
+func F() { dep2.G() }
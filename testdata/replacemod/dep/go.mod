@@ -0,0 +1,5 @@
+module example.com/dep
+
+go 1.22
+
+require example.com/dep2 v1.2.0
@@ -0,0 +1,3 @@
+package dep2
+
+func G() {}
@@ -0,0 +1,12 @@
+module example.com/main
+
+go 1.22
+
+require (
+	example.com/dep v1.0.0
+	example.com/dep2 v1.2.0
+)
+
+replace example.com/dep v1.0.0 => ./dep
+
+replace example.com/dep2 => ./dep2
@@ -0,0 +1,14 @@
+package routes
+
+import "net/http"
+
+const prefix = "/api/"
+
+func index(http.ResponseWriter, *http.Request) {}
+
+func register(mux *http.ServeMux, path string) {
+	http.HandleFunc("/", index)
+	http.Handle(prefix, http.NotFoundHandler())
+	mux.HandleFunc(path, index)
+	mux.Handle("/static/", http.FileServer(http.Dir(".")))
+}
@@ -0,0 +1,17 @@
+package shadow
+
+import "strconv"
+
+var global int
+
+func f(s string) error {
+	n, err := strconv.Atoi(s)
+	if n > 0 {
+		n, err := strconv.Atoi(s)
+		_, _ = n, err
+	}
+	global := 1
+	_ = global
+	func(s string) {}(s)
+	return err
+}
@@ -0,0 +1,15 @@
+package signatures
+
+type T struct{}
+
+func (T) Printf(format string, args ...any) {}
+
+func (*T) Sum(a, b, c, d int, rest ...int) (total int) { return }
+
+func Append(s []int, xs ...int) []int { return nil }
+
+func Fixed(a, b int) (q, r int, err error) { return }
+
+func Many(a, b, c, d, e int) (int, int, int, int) { return 0, 0, 0, 0 }
+
+func few(a, b, c, d, e int) {}
@@ -0,0 +1,45 @@
+package singletons
+
+import "sync"
+
+type Config struct{ Name string }
+
+var config = &Config{}
+
+func Default() *Config { return config }
+
+type registry struct{}
+
+var (
+	reg     *registry
+	regOnce sync.Once
+)
+
+func GetRegistry() *registry {
+	regOnce.Do(func() {
+		reg = &registry{}
+	})
+	return reg
+}
+
+var (
+	names     []string
+	namesOnce sync.Once
+)
+
+func loadNames() {
+	namesOnce.Do(func() { names = []string{"a"} })
+}
+
+var counter int
+
+func Instance() int { return counter }
+
+var local Config
+
+func Get() Config {
+	c := local
+	return c
+}
+
+func Getaway() *Config { return &local }
@@ -0,0 +1,28 @@
+package sizes
+
+import "unsafe"
+
+const PtrSize = unsafe.Sizeof(uintptr(0))
+
+type Small struct {
+	a, b int32
+}
+
+type Large struct {
+	buf [64]byte
+	n   int64
+}
+
+type Padded struct {
+	a bool
+	b int64
+	c bool
+}
+
+type Generic[T any] struct {
+	buf [64]T
+}
+
+type Alias = Large
+
+type Array [128]byte
@@ -0,0 +1,12 @@
+package stringconv
+
+type name string
+
+func f(b []byte, ss []string) int {
+	n := len(string(b))
+	for _, s := range ss {
+		n += len([]byte(s))
+		n += len(name(s))
+	}
+	return n
+}
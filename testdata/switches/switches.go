@@ -0,0 +1,20 @@
+package switches
+
+func f(x any, c chan int) {
+	switch x {
+	case 1:
+	case 2:
+	default:
+	}
+	switch x.(type) {
+	case int, string:
+	case bool:
+	}
+	select {
+	case <-c:
+	case c <- 1:
+	default:
+	}
+	switch {
+	}
+}
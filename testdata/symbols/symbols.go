@@ -0,0 +1,19 @@
+package symbols
+
+type T struct{}
+
+func (T) M() {}
+
+func (*T) m() {}
+
+type u int
+
+func F() {}
+
+func f() {}
+
+func g() {}
+
+var V, v int
+
+const C, c, d = 1, 2, 3
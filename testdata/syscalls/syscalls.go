@@ -0,0 +1,19 @@
+package syscalls
+
+import (
+	"os"
+	"syscall"
+)
+
+func Pid() int {
+	pid, _, _ := syscall.RawSyscall(syscall.SYS_GETPID, 0, 0, 0)
+	return int(pid) + syscall.Getpid() + os.Getpid()
+}
+
+var getenv = syscall.Getenv
+
+func Env() {
+	_, _ = getenv("HOME")
+	var ts syscall.Timespec
+	_ = ts.Nano()
+}
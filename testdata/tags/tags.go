@@ -0,0 +1,20 @@
+package tags
+
+type Doc struct {
+	ID    int    `xml:"id,attr" yaml:"id"`
+	Body  string `xml:",chardata"`
+	Items []int  `yaml:"items,flow" json:"items"`
+	Skip  bool
+}
+
+type plain struct {
+	N int
+}
+
+type Mixed struct {
+	Name   string `json:"name" xml:"name"`
+	Title  string `json:"name" xml:"title"`
+	Secret string `json:"-" xml:"secret"`
+	Hidden string `json:"hidden" xml:"-"`
+	count  int
+}
@@ -0,0 +1,21 @@
+package testcoverage
+
+func Add(a, b int) int { return a + b }
+
+func Sub(a, b int) int { return a - b }
+
+func helper() {}
+
+type T struct{}
+
+func (T) M() {}
+
+func (T) N() {}
+
+func (T) m() {}
+
+type U struct{}
+
+type u struct{}
+
+func (u) M() {}
@@ -0,0 +1,13 @@
+package testcoverage
+
+import "testing"
+
+func TestAdd(t *testing.T) {}
+
+func TestT_M(t *testing.T) {}
+
+func TestU_zero(t *testing.T) {}
+
+func TestSubtract(t *testing.T) {}
+
+func TestHelper(t *testing.T) {}
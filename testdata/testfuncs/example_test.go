@@ -0,0 +1,26 @@
+package testfuncs_test
+
+import (
+	"fmt"
+
+	"github.com/willfaught/forklift/testdata/testfuncs"
+)
+
+func Example() {
+	fmt.Println(testfuncs.Add(1, 2))
+	// Output: 3
+}
+
+func ExampleAdd() {
+	fmt.Println(testfuncs.Add(1, 2))
+	fmt.Println(testfuncs.Add(2, 2))
+	// Output:
+	// 3
+	// 4
+}
+
+func ExampleAdd_negative() {
+	fmt.Println(testfuncs.Add(-1, -2))
+}
+
+func ExampleT_M() {}
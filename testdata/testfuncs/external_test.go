@@ -0,0 +1,7 @@
+package testfuncs_test
+
+import "testing"
+
+func TestExternal(t *testing.T) {}
+
+func BenchmarkExternal(b *testing.B) {}
@@ -0,0 +1,3 @@
+package testfuncs
+
+func Add(a, b int) int { return a + b }
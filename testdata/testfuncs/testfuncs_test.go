@@ -0,0 +1,24 @@
+package testfuncs
+
+import (
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) { os.Exit(m.Run()) }
+
+func TestAdd(t *testing.T) {}
+
+func Test(t *testing.T) {}
+
+func Testing(t *testing.T) {}
+
+func TestHelper(t *testing.T, n int) {}
+
+func BenchmarkAdd(b *testing.B) {}
+
+func Benchmark_add(b *testing.B) {}
+
+func Benchmarker(b *testing.B) {}
+
+func BenchmarkT(t *testing.T) {}
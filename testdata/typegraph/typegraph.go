@@ -0,0 +1,27 @@
+package typegraph
+
+import "io"
+
+type Base struct{}
+
+func (*Base) Read([]byte) (int, error) { return 0, nil }
+
+type Reader interface {
+	Read([]byte) (int, error)
+}
+
+type ReadCloser interface {
+	Reader
+	io.Closer
+}
+
+type Derived struct {
+	*Base
+	Name string
+}
+
+type Alias = Derived
+
+type ID int
+
+type Handler func(ID) Reader
@@ -0,0 +1,31 @@
+package typehierarchy
+
+import "io"
+
+type Base struct{}
+
+func (*Base) Read([]byte) (int, error) { return 0, nil }
+
+func (*Base) Peek(n Size) []byte { return nil }
+
+type Reader interface {
+	Read([]byte) (int, error)
+	Peek(n Size) []byte
+}
+
+type Derived struct {
+	*Base
+	Name string
+	IDs  map[ID][]io.Reader
+	Next *Derived
+}
+
+func (Derived) Handle(h Handler) error { return nil }
+
+type Alias = Derived
+
+type ID int
+
+type Size int
+
+type Handler func(ID) Reader
@@ -0,0 +1,9 @@
+package unsafeuse
+
+import "unsafe"
+
+var x int
+
+var p = unsafe.Pointer(&x)
+
+var s = unsafe.Sizeof(x)
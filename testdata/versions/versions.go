@@ -0,0 +1,19 @@
+package versions
+
+import (
+	"slices"
+	"strings"
+)
+
+type List[T any] []T
+
+func f(s string) int {
+	before, _, _ := strings.Cut(s, ",")
+	n := 0b101
+	for i := range 3 {
+		n += i
+	}
+	return n + len(before) + min(1, 2)
+}
+
+var _ = slices.Contains[[]int]
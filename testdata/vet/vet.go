@@ -0,0 +1,15 @@
+package vet
+
+import "fmt"
+
+func F(n int) int {
+	fmt.Printf("%s\n", n)
+	n = n
+	return n
+	fmt.Println("unreachable")
+	panic(n)
+}
+
+type T struct {
+	Name string `json:name`
+}
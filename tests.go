@@ -0,0 +1,292 @@
+package forklift
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// FindPackagesWithoutTests returns the import paths of the packages in ps that have no test files.
+// Packages with only external test files have tests.
+// It requires names.
+func FindPackagesWithoutTests(ps []*packages.Package) ([]string, error) {
+	l := Loader{Mode: packages.NeedName | packages.NeedFiles}
+	var paths []string
+	for _, p := range ps {
+		if p == nil {
+			return nil, ErrNotFound
+		}
+		if p.PkgPath == "" {
+			return nil, ErrNotLoaded
+		}
+		_, err := l.LoadTestPackage(p.PkgPath)
+		if errors.Is(err, ErrNotFound) {
+			_, err = l.LoadExternalTestPackage(p.PkgPath)
+		}
+		if errors.Is(err, ErrNotFound) {
+			paths = append(paths, p.PkgPath)
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	return paths, nil
+}
+
+// isTestFunc returns whether d is a test function with the name prefix and the parameter type,
+// like "Test" and "*testing.T".
+// The name must be the prefix, or the prefix followed by a character that is not a lowercase letter.
+func isTestFunc(p *packages.Package, d *ast.FuncDecl, prefix, param string) bool {
+	if d.Recv != nil || d.Type.TypeParams != nil || !strings.HasPrefix(d.Name.Name, prefix) {
+		return false
+	}
+	if r, _ := utf8.DecodeRuneInString(d.Name.Name[len(prefix):]); unicode.IsLower(r) {
+		return false
+	}
+	f, ok := p.TypesInfo.Defs[d.Name].(*types.Func)
+	if !ok {
+		return false
+	}
+	s := f.Type().(*types.Signature)
+	return s.Params().Len() == 1 && s.Results().Len() == 0 && types.TypeString(s.Params().At(0).Type(), nil) == param
+}
+
+// TestFuncInfo is a test function declaration.
+type TestFuncInfo struct {
+	// Name is the function name.
+	Name string
+
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+
+	// Main is whether the function is TestMain.
+	Main bool
+}
+
+// FindTestFunctions returns the test function declarations in p,
+// like "func TestName(*testing.T)" and "func TestMain(*testing.M)".
+// It requires syntax and types info.
+func FindTestFunctions(p *packages.Package) ([]TestFuncInfo, error) {
+	if err := need(p, packages.NeedSyntax|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	var tests []TestFuncInfo
+	for _, f := range p.Syntax {
+		for _, d := range f.Decls {
+			d, ok := d.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			main := d.Name.Name == "TestMain" && isTestFunc(p, d, "TestMain", "*testing.M")
+			if main || isTestFunc(p, d, "Test", "*testing.T") {
+				file, line := position(p, d.Pos())
+				tests = append(tests, TestFuncInfo{Name: d.Name.Name, File: file, Line: line, Main: main})
+			}
+		}
+	}
+	return tests, nil
+}
+
+// FindBenchmarkFunctions returns the sorted names of the benchmark functions in p,
+// like "func BenchmarkName(*testing.B)".
+// It requires syntax and types info.
+func FindBenchmarkFunctions(p *packages.Package) ([]string, error) {
+	if err := need(p, packages.NeedSyntax|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, f := range p.Syntax {
+		for _, d := range f.Decls {
+			if d, ok := d.(*ast.FuncDecl); ok && isTestFunc(p, d, "Benchmark", "*testing.B") {
+				names = append(names, d.Name.Name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// CoverageEstimate is an estimate of the exported symbols covered by tests.
+type CoverageEstimate struct {
+	// TestedSymbols is the sorted names of the tested symbols.
+	TestedSymbols []string
+
+	// UntestedSymbols is the sorted names of the untested symbols.
+	UntestedSymbols []string
+
+	// Ratio is the number of tested symbols divided by the number of symbols,
+	// or 1 if there are no symbols.
+	Ratio float64
+}
+
+// ComputeTestCoverage returns an estimate of the exported symbols in pkg covered by the tests in testPkg.
+// The symbols are the exported functions and types, and the exported methods of exported types,
+// like "T.M".
+// A symbol is tested if there is a test function named "Test" followed by the symbol name,
+// optionally followed by an underscore and a suffix, with an underscore in place of a method dot,
+// like "TestF", "TestF_suffix", and "TestT_M".
+// It requires types for pkg, and syntax and types info for testPkg.
+func ComputeTestCoverage(pkg, testPkg *packages.Package) (*CoverageEstimate, error) {
+	if err := need(pkg, packages.NeedTypes); err != nil {
+		return nil, err
+	}
+	tests, err := FindTestFunctions(testPkg)
+	if err != nil {
+		return nil, err
+	}
+	var symbols []string
+	scope := pkg.Types.Scope()
+	for _, name := range scope.Names() {
+		switch obj := scope.Lookup(name).(type) {
+		case *types.Func:
+			if obj.Exported() {
+				symbols = append(symbols, name)
+			}
+		case *types.TypeName:
+			if !obj.Exported() {
+				continue
+			}
+			symbols = append(symbols, name)
+			if named, ok := obj.Type().(*types.Named); ok && !obj.IsAlias() {
+				for i := 0; i < named.NumMethods(); i++ {
+					if m := named.Method(i); m.Exported() {
+						symbols = append(symbols, name+"."+m.Name())
+					}
+				}
+			}
+		}
+	}
+	sort.Strings(symbols)
+	e := &CoverageEstimate{Ratio: 1}
+	for _, symbol := range symbols {
+		name := "Test" + strings.ReplaceAll(symbol, ".", "_")
+		var tested bool
+		for _, test := range tests {
+			if !test.Main && (test.Name == name || strings.HasPrefix(test.Name, name+"_")) {
+				tested = true
+				break
+			}
+		}
+		if tested {
+			e.TestedSymbols = append(e.TestedSymbols, symbol)
+		} else {
+			e.UntestedSymbols = append(e.UntestedSymbols, symbol)
+		}
+	}
+	if len(symbols) > 0 {
+		e.Ratio = float64(len(e.TestedSymbols)) / float64(len(symbols))
+	}
+	return e, nil
+}
+
+// FindPackagesBelowCoverage returns the import paths of the packages in ps
+// with a [ComputeTestCoverage] estimate below minRatio,
+// where testPkgs[i] is the test package of ps[i].
+// It requires types for ps, and syntax and types info for testPkgs.
+func FindPackagesBelowCoverage(ps []*packages.Package, testPkgs []*packages.Package, minRatio float64) ([]string, error) {
+	if len(ps) != len(testPkgs) {
+		return nil, fmt.Errorf("%d packages but %d test packages", len(ps), len(testPkgs))
+	}
+	var paths []string
+	for i, p := range ps {
+		e, err := ComputeTestCoverage(p, testPkgs[i])
+		if err != nil {
+			return nil, err
+		}
+		if e.Ratio < minRatio {
+			paths = append(paths, p.PkgPath)
+		}
+	}
+	return paths, nil
+}
+
+// ExampleInfo is an example function declaration.
+type ExampleInfo struct {
+	// Name is the function name.
+	Name string
+
+	// Target is the documented identifier, like "F" or "T.M", or empty for the package.
+	Target string
+
+	// ExpectedOutput is the text of the output comment, or empty if there is none.
+	ExpectedOutput string
+}
+
+// FindExampleFunctions returns the example function declarations in p, sorted by name.
+// It requires syntax.
+func FindExampleFunctions(p *packages.Package) ([]ExampleInfo, error) {
+	if err := need(p, packages.NeedSyntax); err != nil {
+		return nil, err
+	}
+	var examples []ExampleInfo
+	for _, e := range doc.Examples(p.Syntax...) {
+		target := e.Name
+		if i := strings.LastIndex(target, "_"); i >= 0 {
+			if r, _ := utf8.DecodeRuneInString(target[i+1:]); unicode.IsLower(r) {
+				target = target[:i]
+			}
+		}
+		examples = append(examples, ExampleInfo{
+			Name:           "Example" + e.Name,
+			Target:         strings.ReplaceAll(target, "_", "."),
+			ExpectedOutput: e.Output,
+		})
+	}
+	return examples, nil
+}
+
+// FindTestDataDirs returns the absolute paths of the testdata directories
+// in the directories of the files of p, and in their subdirectories, sorted.
+// It requires files.
+func FindTestDataDirs(p *packages.Package) ([]string, error) {
+	if p == nil {
+		return nil, ErrNotFound
+	}
+	seen := map[string]bool{}
+	var dirs []string
+	add := func(dir string) {
+		dir = filepath.Join(dir, "testdata")
+		if seen[dir] {
+			return
+		}
+		seen[dir] = true
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			dirs = append(dirs, dir)
+		}
+	}
+	visited := map[string]bool{}
+	for _, f := range p.GoFiles {
+		dir, err := filepath.Abs(filepath.Dir(f))
+		if err != nil {
+			return nil, err
+		}
+		if visited[dir] {
+			continue
+		}
+		visited[dir] = true
+		add(dir)
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			if e.IsDir() {
+				add(filepath.Join(dir, e.Name()))
+			}
+		}
+	}
+	sort.Strings(dirs)
+	return dirs, nil
+}
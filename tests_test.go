@@ -0,0 +1,113 @@
+package forklift
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestFindPackagesWithoutTests(t *testing.T) {
+	t.Parallel()
+	paths, err := FindPackagesWithoutTests([]*packages.Package{
+		loadTestdata(t, "typegraph"),
+		{PkgPath: "github.com/willfaught/forklift"},
+		{PkgPath: "strings"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"github.com/willfaught/forklift/testdata/typegraph"}, paths)
+	_, err = FindPackagesWithoutTests([]*packages.Package{{}})
+	assert.Equal(t, ErrNotLoaded, err)
+}
+
+func TestFindTestFunctions(t *testing.T) {
+	t.Parallel()
+	p, err := LoadTestPackage("./testdata/testfuncs")
+	require.NoError(t, err)
+	tests, err := FindTestFunctions(p)
+	require.NoError(t, err)
+	for i := range tests {
+		tests[i].File = ""
+	}
+	assert.Equal(t, []TestFuncInfo{
+		{Name: "TestMain", Line: 8, Main: true},
+		{Name: "TestAdd", Line: 10},
+		{Name: "Test", Line: 12},
+	}, tests)
+}
+
+func TestFindBenchmarkFunctions(t *testing.T) {
+	t.Parallel()
+	p, err := LoadTestPackage("./testdata/testfuncs")
+	require.NoError(t, err)
+	names, err := FindBenchmarkFunctions(p)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BenchmarkAdd", "Benchmark_add"}, names)
+	p, err = LoadExternalTestPackage("./testdata/testfuncs")
+	require.NoError(t, err)
+	names, err = FindBenchmarkFunctions(p)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BenchmarkExternal"}, names)
+}
+
+func TestComputeTestCoverage(t *testing.T) {
+	t.Parallel()
+	testPkg, err := LoadTestPackage("./testdata/testcoverage")
+	require.NoError(t, err)
+	e, err := ComputeTestCoverage(loadTestdata(t, "testcoverage"), testPkg)
+	require.NoError(t, err)
+	assert.Equal(t, &CoverageEstimate{
+		TestedSymbols:   []string{"Add", "T", "T.M", "U"},
+		UntestedSymbols: []string{"Sub", "T.N"},
+		Ratio:           4.0 / 6,
+	}, e)
+	_, err = ComputeTestCoverage(nil, testPkg)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestFindPackagesBelowCoverage(t *testing.T) {
+	t.Parallel()
+	var ps, testPkgs []*packages.Package
+	for _, name := range []string{"testcoverage", "testfuncs"} {
+		ps = append(ps, loadTestdata(t, name))
+		testPkg, err := LoadTestPackage("./testdata/" + name)
+		require.NoError(t, err)
+		testPkgs = append(testPkgs, testPkg)
+	}
+	paths, err := FindPackagesBelowCoverage(ps, testPkgs, 0.9)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"github.com/willfaught/forklift/testdata/testcoverage"}, paths)
+	paths, err = FindPackagesBelowCoverage(ps, testPkgs, 0.5)
+	require.NoError(t, err)
+	assert.Empty(t, paths)
+	_, err = FindPackagesBelowCoverage(ps, testPkgs[:1], 0.5)
+	assert.Error(t, err)
+}
+
+func TestFindExampleFunctions(t *testing.T) {
+	t.Parallel()
+	p, err := LoadExternalTestPackage("./testdata/testfuncs")
+	require.NoError(t, err)
+	examples, err := FindExampleFunctions(p)
+	require.NoError(t, err)
+	assert.Equal(t, []ExampleInfo{
+		{Name: "Example", ExpectedOutput: "3\n"},
+		{Name: "ExampleAdd", Target: "Add", ExpectedOutput: "3\n4\n"},
+		{Name: "ExampleAdd_negative", Target: "Add"},
+		{Name: "ExampleT_M", Target: "T.M"},
+	}, examples)
+}
+
+func TestFindTestDataDirs(t *testing.T) {
+	t.Parallel()
+	dirs, err := FindTestDataDirs(loadTestdata(t, "testdatadirs"))
+	require.NoError(t, err)
+	dir, err := filepath.Abs("testdata/testdatadirs")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "sub", "testdata"),
+		filepath.Join(dir, "testdata"),
+	}, dirs)
+}
@@ -0,0 +1,175 @@
+package forklift
+
+import (
+	"encoding/json"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// TypeNode is a named type.
+type TypeNode struct {
+	// Name is the type name.
+	Name string `json:"name"`
+
+	// Kind is "alias" or the underlying type kind, like "struct", "interface", or "func".
+	Kind string `json:"kind"`
+}
+
+// TypeEdge is a relationship between two types.
+type TypeEdge struct {
+	// From is the type name.
+	From string `json:"from"`
+
+	// To is the related type name, qualified by its package path if it is in another package.
+	To string `json:"to"`
+
+	// Kind is "embeds", "implements", "aliases", or "uses".
+	Kind string `json:"kind"`
+}
+
+// TypeGraph is the named types in a package and their relationships.
+type TypeGraph struct {
+	// Nodes is the types.
+	Nodes []TypeNode `json:"nodes"`
+
+	// Edges is the relationships.
+	Edges []TypeEdge `json:"edges"`
+}
+
+// typeKind returns the kind of t, like "struct" or "interface".
+func typeKind(t types.Type) string {
+	switch t.Underlying().(type) {
+	case *types.Array:
+		return "array"
+	case *types.Basic:
+		return "basic"
+	case *types.Chan:
+		return "chan"
+	case *types.Interface:
+		return "interface"
+	case *types.Map:
+		return "map"
+	case *types.Pointer:
+		return "pointer"
+	case *types.Signature:
+		return "func"
+	case *types.Slice:
+		return "slice"
+	case *types.Struct:
+		return "struct"
+	}
+	return "other"
+}
+
+// buildTypeGraph returns the named types in p and their embedding, implementation, and alias relationships,
+// and their use relationships if uses.
+func buildTypeGraph(p *packages.Package, uses bool) *TypeGraph {
+	var g TypeGraph
+	qualifier := types.RelativeTo(p.Types)
+	name := func(t types.Type) string {
+		if ptr, ok := t.(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		return types.TypeString(t, qualifier)
+	}
+	var interfaces []*types.TypeName
+	scope := p.Types.Scope()
+	for _, n := range scope.Names() {
+		t, ok := scope.Lookup(n).(*types.TypeName)
+		if !ok {
+			continue
+		}
+		if t.IsAlias() {
+			g.Nodes = append(g.Nodes, TypeNode{Name: n, Kind: "alias"})
+			g.Edges = append(g.Edges, TypeEdge{From: n, To: name(types.Unalias(t.Type())), Kind: "aliases"})
+			continue
+		}
+		g.Nodes = append(g.Nodes, TypeNode{Name: n, Kind: typeKind(t.Type())})
+		used := map[string]bool{n: true}
+		use := func(named *types.Named) {
+			if !uses || named.Obj().Pkg() == nil {
+				return
+			}
+			if to := name(named); !used[to] {
+				used[to] = true
+				g.Edges = append(g.Edges, TypeEdge{From: n, To: to, Kind: "uses"})
+			}
+		}
+		switch u := t.Type().Underlying().(type) {
+		case *types.Struct:
+			for i := 0; i < u.NumFields(); i++ {
+				if f := u.Field(i); f.Embedded() {
+					g.Edges = append(g.Edges, TypeEdge{From: n, To: name(f.Type()), Kind: "embeds"})
+				} else {
+					namedTypes(f.Type(), use)
+				}
+			}
+		case *types.Interface:
+			for i := 0; i < u.NumEmbeddeds(); i++ {
+				if e, ok := u.EmbeddedType(i).(*types.Named); ok {
+					g.Edges = append(g.Edges, TypeEdge{From: n, To: name(e), Kind: "embeds"})
+				}
+			}
+			for i := 0; i < u.NumExplicitMethods(); i++ {
+				namedTypes(u.ExplicitMethod(i).Type(), use)
+			}
+			if u.NumMethods() > 0 {
+				interfaces = append(interfaces, t)
+			}
+		default:
+			namedTypes(u, use)
+		}
+		if named, ok := t.Type().(*types.Named); ok {
+			for i := 0; i < named.NumMethods(); i++ {
+				namedTypes(named.Method(i).Type(), use)
+			}
+		}
+	}
+	for _, n := range scope.Names() {
+		t, ok := scope.Lookup(n).(*types.TypeName)
+		if !ok || t.IsAlias() || types.IsInterface(t.Type()) {
+			continue
+		}
+		for _, i := range interfaces {
+			iface := i.Type().Underlying().(*types.Interface)
+			if types.Implements(t.Type(), iface) || types.Implements(types.NewPointer(t.Type()), iface) {
+				g.Edges = append(g.Edges, TypeEdge{From: n, To: i.Name(), Kind: "implements"})
+			}
+		}
+	}
+	return &g
+}
+
+// ExtractTypeHierarchy returns the package-level named types in p, sorted by name, and their relationships.
+// Edge kinds are "aliases" for type aliases,
+// "embeds" for embedded struct fields and interfaces,
+// "uses" for other named types in the underlying types and method signatures,
+// and "implements" for types whose values or pointers implement interfaces in p.
+// Predeclared types like error are not used.
+// It requires types.
+func ExtractTypeHierarchy(p *packages.Package) (*TypeGraph, error) {
+	if err := need(p, packages.NeedTypes); err != nil {
+		return nil, err
+	}
+	return buildTypeGraph(p, true), nil
+}
+
+// ExportTypeGraph returns a JSON document of the named types in p and their relationships.
+// The document is like
+//
+//	{"nodes":[{"name":"T","kind":"struct"}],"edges":[{"from":"T","to":"io.Reader","kind":"embeds"}]}
+//
+// Node kinds are "alias" or the underlying type kind, like "struct", "interface", or "func".
+// Edge kinds are "embeds" for embedded struct fields and interfaces,
+// "implements" for types whose values or pointers implement interfaces in p,
+// and "aliases" for type aliases.
+// Unlike [ExtractTypeHierarchy], "uses" edges are not included.
+// Types in other packages are qualified by their package path.
+// It requires types.
+func ExportTypeGraph(p *packages.Package) ([]byte, error) {
+	if err := need(p, packages.NeedTypes); err != nil {
+		return nil, err
+	}
+	return json.Marshal(buildTypeGraph(p, false))
+}
@@ -0,0 +1,76 @@
+package forklift
+
+import (
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportTypeGraph(t *testing.T) {
+	t.Parallel()
+	b, err := ExportTypeGraph(loadTestdata(t, "typegraph"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"nodes": [
+			{"name": "Alias", "kind": "alias"},
+			{"name": "Base", "kind": "struct"},
+			{"name": "Derived", "kind": "struct"},
+			{"name": "Handler", "kind": "func"},
+			{"name": "ID", "kind": "basic"},
+			{"name": "ReadCloser", "kind": "interface"},
+			{"name": "Reader", "kind": "interface"}
+		],
+		"edges": [
+			{"from": "Alias", "to": "Derived", "kind": "aliases"},
+			{"from": "Derived", "to": "Base", "kind": "embeds"},
+			{"from": "ReadCloser", "to": "Reader", "kind": "embeds"},
+			{"from": "ReadCloser", "to": "io.Closer", "kind": "embeds"},
+			{"from": "Base", "to": "Reader", "kind": "implements"},
+			{"from": "Derived", "to": "Reader", "kind": "implements"}
+		]
+	}`, string(b))
+}
+
+func TestExtractTypeHierarchy(t *testing.T) {
+	t.Parallel()
+	g, err := ExtractTypeHierarchy(loadTestdata(t, "typehierarchy"))
+	require.NoError(t, err)
+	assert.Equal(t, &TypeGraph{
+		Nodes: []TypeNode{
+			{Name: "Alias", Kind: "alias"},
+			{Name: "Base", Kind: "struct"},
+			{Name: "Derived", Kind: "struct"},
+			{Name: "Handler", Kind: "func"},
+			{Name: "ID", Kind: "basic"},
+			{Name: "Reader", Kind: "interface"},
+			{Name: "Size", Kind: "basic"},
+		},
+		Edges: []TypeEdge{
+			{From: "Alias", To: "Derived", Kind: "aliases"},
+			{From: "Base", To: "Size", Kind: "uses"},
+			{From: "Derived", To: "Base", Kind: "embeds"},
+			{From: "Derived", To: "ID", Kind: "uses"},
+			{From: "Derived", To: "io.Reader", Kind: "uses"},
+			{From: "Derived", To: "Handler", Kind: "uses"},
+			{From: "Handler", To: "ID", Kind: "uses"},
+			{From: "Handler", To: "Reader", Kind: "uses"},
+			{From: "Reader", To: "Size", Kind: "uses"},
+			{From: "Base", To: "Reader", Kind: "implements"},
+			{From: "Derived", To: "Reader", Kind: "implements"},
+		},
+	}, g)
+	_, err = ExtractTypeHierarchy(nil)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestExportTypeGraphAliases(t *testing.T) {
+	t.Setenv("GODEBUG", "gotypesalias=1")
+	p := loadTestdata(t, "typegraph")
+	_, ok := p.Types.Scope().Lookup("Alias").Type().(*types.Alias)
+	require.True(t, ok)
+	b, err := ExportTypeGraph(p)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `{"from":"Alias","to":"Derived","kind":"aliases"}`)
+}
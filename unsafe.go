@@ -0,0 +1,77 @@
+package forklift
+
+import (
+	"go/ast"
+	"go/types"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// UnsafeReport is the use of the unsafe package by a package and its dependencies.
+type UnsafeReport struct {
+	// DirectUses is the uses of the unsafe package in the package.
+	DirectUses []UnsafeCast
+
+	// TransitiveImporters is the import paths of the dependencies that import unsafe or C.
+	TransitiveImporters []string
+}
+
+// UnsafeCast is a use of the unsafe package.
+type UnsafeCast struct {
+	// Expr is the expression, like "unsafe.Pointer".
+	Expr string
+
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+}
+
+func usesUnsafe(p *packages.Package) bool {
+	_, unsafe := p.Imports["unsafe"]
+	_, c := p.Imports["C"]
+	return unsafe || c
+}
+
+// DetectUnsafeUsage returns the use of the unsafe package by p and its dependencies.
+// It requires syntax, types info, and imports.
+func DetectUnsafeUsage(p *packages.Package) (*UnsafeReport, error) {
+	if err := need(p, packages.NeedSyntax|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	var r UnsafeReport
+	if usesUnsafe(p) {
+		for _, f := range p.Syntax {
+			ast.Inspect(f, func(n ast.Node) bool {
+				sel, ok := n.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+				if obj := p.TypesInfo.Uses[sel.Sel]; obj != nil && obj.Pkg() == types.Unsafe {
+					file, line := position(p, sel.Pos())
+					r.DirectUses = append(r.DirectUses, UnsafeCast{Expr: types.ExprString(sel), File: file, Line: line})
+				}
+				return true
+			})
+		}
+	}
+	seen := map[string]bool{p.PkgPath: true}
+	var visit func(*packages.Package)
+	visit = func(p *packages.Package) {
+		for _, dep := range p.Imports {
+			if seen[dep.PkgPath] {
+				continue
+			}
+			seen[dep.PkgPath] = true
+			if usesUnsafe(dep) {
+				r.TransitiveImporters = append(r.TransitiveImporters, dep.PkgPath)
+			}
+			visit(dep)
+		}
+	}
+	visit(p)
+	sort.Strings(r.TransitiveImporters)
+	return &r, nil
+}
@@ -0,0 +1,29 @@
+package forklift
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectUnsafeUsage(t *testing.T) {
+	t.Parallel()
+	p := loadTestdata(t, "unsafeuse")
+	r, err := DetectUnsafeUsage(p)
+	require.NoError(t, err)
+	require.Len(t, r.DirectUses, 2)
+	assert.Equal(t, "unsafe.Pointer", r.DirectUses[0].Expr)
+	assert.Equal(t, "unsafeuse.go", filepath.Base(r.DirectUses[0].File))
+	assert.Equal(t, 7, r.DirectUses[0].Line)
+	assert.Equal(t, "unsafe.Sizeof", r.DirectUses[1].Expr)
+	p, err = LoadPackage("fmt")
+	require.NoError(t, err)
+	r, err = DetectUnsafeUsage(p)
+	require.NoError(t, err)
+	assert.Empty(t, r.DirectUses)
+	assert.Contains(t, r.TransitiveImporters, "reflect")
+	_, err = DetectUnsafeUsage(nil)
+	assert.Equal(t, ErrNotFound, err)
+}
@@ -0,0 +1,179 @@
+package forklift
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"go/version"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// versionUse is the use of a language feature or standard library symbol introduced in a Go version.
+type versionUse struct {
+	symbol  string
+	version string
+	pos     token.Pos
+}
+
+// versionUses returns the uses in p of language features and standard library symbols
+// introduced in Go 1.9 or later, sorted by position.
+func versionUses(p *packages.Package) []versionUse {
+	var uses []versionUse
+	add := func(symbol, version string, pos token.Pos) {
+		uses = append(uses, versionUse{symbol: symbol, version: version, pos: pos})
+	}
+	for _, f := range p.Syntax {
+		for _, spec := range f.Imports {
+			path := strings.Trim(spec.Path.Value, "`\"")
+			if v, ok := stdlibPackageVersions[path]; ok {
+				add(path, v, spec.Pos())
+			}
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.TypeSpec:
+				if n.Assign.IsValid() {
+					add("type alias", "1.9", n.Pos())
+				}
+				if n.TypeParams != nil {
+					add("type parameters", "1.18", n.Pos())
+				}
+			case *ast.FuncType:
+				if n.TypeParams != nil {
+					add("type parameters", "1.18", n.Pos())
+				}
+			case *ast.BasicLit:
+				if n.Kind != token.INT && n.Kind != token.FLOAT && n.Kind != token.IMAG {
+					break
+				}
+				lit := strings.ToLower(n.Value)
+				if strings.Contains(lit, "_") || strings.HasPrefix(lit, "0b") || strings.HasPrefix(lit, "0o") {
+					add("number literal prefix or separator", "1.13", n.Pos())
+				}
+			case *ast.RangeStmt:
+				t := p.TypesInfo.TypeOf(n.X)
+				if t == nil {
+					break
+				}
+				switch u := t.Underlying().(type) {
+				case *types.Basic:
+					if u.Info()&types.IsInteger != 0 {
+						add("range over int", "1.22", n.Pos())
+					}
+				case *types.Signature:
+					add("range over func", "1.23", n.Pos())
+				}
+			case *ast.CallExpr:
+				if len(n.Args) != 1 || !p.TypesInfo.Types[n.Fun].IsType() {
+					break
+				}
+				to, from := p.TypesInfo.TypeOf(n.Fun), p.TypesInfo.TypeOf(n.Args[0])
+				if to == nil || from == nil {
+					break
+				}
+				if _, ok := from.Underlying().(*types.Slice); !ok {
+					break
+				}
+				switch to := to.Underlying().(type) {
+				case *types.Array:
+					add("slice to array conversion", "1.20", n.Pos())
+				case *types.Pointer:
+					if _, ok := to.Elem().Underlying().(*types.Array); ok {
+						add("slice to array pointer conversion", "1.17", n.Pos())
+					}
+				}
+			case *ast.Ident:
+				obj := p.TypesInfo.Uses[n]
+				if obj == nil {
+					break
+				}
+				if obj.Parent() == types.Universe {
+					switch obj.Name() {
+					case "any", "comparable":
+						add(obj.Name(), "1.18", n.Pos())
+					case "clear", "max", "min":
+						add(obj.Name(), "1.21", n.Pos())
+					}
+					break
+				}
+				if obj.Pkg() == nil || obj.Parent() != obj.Pkg().Scope() {
+					break
+				}
+				symbol := obj.Pkg().Path() + "." + obj.Name()
+				if v, ok := stdlibSymbolVersions[symbol]; ok {
+					add(symbol, v, n.Pos())
+				}
+			}
+			return true
+		})
+	}
+	sort.SliceStable(uses, func(i, j int) bool {
+		return uses[i].pos < uses[j].pos
+	})
+	return uses
+}
+
+// DetectMinimumGoVersion returns the minimum Go version required by p, like "1.21".
+// It is the greatest of the module Go version, if known,
+// and the versions that introduced the language features and standard library symbols used by p.
+// Only the standard library symbols in a bundled manifest of common packages are considered.
+// It requires syntax and types info.
+func DetectMinimumGoVersion(p *packages.Package) (string, error) {
+	if err := need(p, packages.NeedSyntax|packages.NeedTypesInfo); err != nil {
+		return "", err
+	}
+	required := "go1"
+	if p.Module != nil && p.Module.GoVersion != "" {
+		if v := version.Lang("go" + p.Module.GoVersion); v != "" {
+			required = v
+		}
+	}
+	for _, use := range versionUses(p) {
+		if v := "go" + use.version; version.Compare(v, required) > 0 {
+			required = v
+		}
+	}
+	return strings.TrimPrefix(required, "go"), nil
+}
+
+// CompatError is the use of a language feature or standard library symbol
+// that is not available in a Go version.
+type CompatError struct {
+	// Symbol is the feature, like "range over int", or the symbol, like "strings.Cut".
+	Symbol string
+
+	// IntroducedIn is the Go version that introduced the feature or symbol, like "1.22".
+	IntroducedIn string
+
+	// File is the file name.
+	File string
+
+	// Line is the line number.
+	Line int
+}
+
+// CheckGoVersionCompatibility returns the uses in p of language features and standard library symbols
+// that are not available in goVersion, like "1.21".
+// Only the standard library symbols in a bundled manifest of common packages are considered.
+// It requires syntax and types info.
+func CheckGoVersionCompatibility(p *packages.Package, goVersion string) ([]CompatError, error) {
+	if err := need(p, packages.NeedSyntax|packages.NeedTypesInfo); err != nil {
+		return nil, err
+	}
+	target := "go" + strings.TrimPrefix(goVersion, "go")
+	if !version.IsValid(target) {
+		return nil, fmt.Errorf("invalid Go version: %s", goVersion)
+	}
+	var errs []CompatError
+	for _, use := range versionUses(p) {
+		if version.Compare("go"+use.version, version.Lang(target)) > 0 {
+			file, line := position(p, use.pos)
+			errs = append(errs, CompatError{Symbol: use.symbol, IntroducedIn: use.version, File: file, Line: line})
+		}
+	}
+	return errs, nil
+}
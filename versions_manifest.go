@@ -0,0 +1,189 @@
+package forklift
+
+// stdlibPackageVersions is the Go versions that introduced standard library packages,
+// starting with Go 1.13.
+var stdlibPackageVersions = map[string]string{
+	"cmp":                 "1.21",
+	"crypto/ecdh":         "1.20",
+	"crypto/ed25519":      "1.13",
+	"debug/buildinfo":     "1.18",
+	"embed":               "1.16",
+	"go/build/constraint": "1.16",
+	"go/doc/comment":      "1.19",
+	"go/version":          "1.22",
+	"hash/maphash":        "1.14",
+	"io/fs":               "1.16",
+	"iter":                "1.23",
+	"log/slog":            "1.21",
+	"maps":                "1.21",
+	"math/rand/v2":        "1.22",
+	"net/netip":           "1.18",
+	"runtime/coverage":    "1.20",
+	"runtime/metrics":     "1.16",
+	"slices":              "1.21",
+	"structs":             "1.23",
+	"testing/fstest":      "1.16",
+	"testing/slogtest":    "1.21",
+	"unique":              "1.23",
+}
+
+// stdlibSymbolVersions is the Go versions that introduced package-level functions and types
+// in commonly used standard library packages, starting with Go 1.13.
+var stdlibSymbolVersions = map[string]string{
+	"bytes.Clone":                             "1.20",
+	"bytes.ContainsFunc":                      "1.21",
+	"bytes.Cut":                               "1.18",
+	"bytes.CutPrefix":                         "1.20",
+	"bytes.CutSuffix":                         "1.20",
+	"bytes.ToValidUTF8":                       "1.13",
+	"context.AfterFunc":                       "1.21",
+	"context.CancelCauseFunc":                 "1.20",
+	"context.Cause":                           "1.20",
+	"context.WithCancelCause":                 "1.20",
+	"context.WithDeadlineCause":               "1.21",
+	"context.WithTimeoutCause":                "1.21",
+	"context.WithoutCancel":                   "1.21",
+	"crypto/tls.AlertError":                   "1.21",
+	"crypto/tls.CertificateVerificationError": "1.20",
+	"crypto/tls.CipherSuite":                  "1.14",
+	"crypto/tls.CipherSuiteName":              "1.14",
+	"crypto/tls.CipherSuites":                 "1.14",
+	"crypto/tls.Dialer":                       "1.15",
+	"crypto/tls.ECHRejectionError":            "1.23",
+	"crypto/tls.InsecureCipherSuites":         "1.14",
+	"crypto/tls.NewResumptionState":           "1.21",
+	"crypto/tls.ParseSessionState":            "1.21",
+	"crypto/tls.QUICClient":                   "1.21",
+	"crypto/tls.QUICConfig":                   "1.21",
+	"crypto/tls.QUICConn":                     "1.21",
+	"crypto/tls.QUICEncryptionLevel":          "1.21",
+	"crypto/tls.QUICEvent":                    "1.21",
+	"crypto/tls.QUICEventKind":                "1.21",
+	"crypto/tls.QUICServer":                   "1.21",
+	"crypto/tls.QUICSessionTicketOptions":     "1.21",
+	"crypto/tls.SessionState":                 "1.21",
+	"crypto/tls.VersionName":                  "1.21",
+	"encoding/binary.Append":                  "1.23",
+	"encoding/binary.AppendByteOrder":         "1.19",
+	"encoding/binary.AppendUvarint":           "1.19",
+	"encoding/binary.AppendVarint":            "1.19",
+	"encoding/binary.Decode":                  "1.23",
+	"encoding/binary.Encode":                  "1.23",
+	"errors.As":                               "1.13",
+	"errors.Is":                               "1.13",
+	"errors.Join":                             "1.20",
+	"errors.Unwrap":                           "1.13",
+	"fmt.Append":                              "1.19",
+	"fmt.Appendf":                             "1.19",
+	"fmt.Appendln":                            "1.19",
+	"fmt.FormatString":                        "1.20",
+	"go/ast.IndexListExpr":                    "1.18",
+	"go/ast.IsGenerated":                      "1.21",
+	"go/ast.Preorder":                         "1.23",
+	"go/ast.Unparen":                          "1.22",
+	"go/types.Alias":                          "1.22",
+	"go/types.ArgumentError":                  "1.18",
+	"go/types.CheckExpr":                      "1.13",
+	"go/types.Context":                        "1.18",
+	"go/types.Instance":                       "1.18",
+	"go/types.Instantiate":                    "1.18",
+	"go/types.NewAlias":                       "1.22",
+	"go/types.NewContext":                     "1.18",
+	"go/types.NewSignatureType":               "1.18",
+	"go/types.NewTerm":                        "1.18",
+	"go/types.NewTypeParam":                   "1.18",
+	"go/types.NewUnion":                       "1.18",
+	"go/types.Satisfies":                      "1.20",
+	"go/types.Term":                           "1.18",
+	"go/types.TypeList":                       "1.18",
+	"go/types.TypeParam":                      "1.18",
+	"go/types.TypeParamList":                  "1.18",
+	"go/types.Unalias":                        "1.22",
+	"go/types.Union":                          "1.18",
+	"io.NewOffsetWriter":                      "1.20",
+	"io.NopCloser":                            "1.16",
+	"io.OffsetWriter":                         "1.20",
+	"io.ReadAll":                              "1.16",
+	"io.ReadSeekCloser":                       "1.16",
+	"math.FMA":                                "1.14",
+	"math/bits.Rem":                           "1.14",
+	"math/bits.Rem32":                         "1.14",
+	"math/bits.Rem64":                         "1.14",
+	"net.KeepAliveConfig":                     "1.23",
+	"net.TCPAddrFromAddrPort":                 "1.18",
+	"net.UDPAddrFromAddrPort":                 "1.18",
+	"net/http.AllowQuerySemicolons":           "1.17",
+	"net/http.FS":                             "1.16",
+	"net/http.FileServerFS":                   "1.22",
+	"net/http.MaxBytesError":                  "1.19",
+	"net/http.MaxBytesHandler":                "1.18",
+	"net/http.NewFileTransportFS":             "1.22",
+	"net/http.NewRequestWithContext":          "1.13",
+	"net/http.NewResponseController":          "1.20",
+	"net/http.ParseCookie":                    "1.23",
+	"net/http.ParseSetCookie":                 "1.23",
+	"net/http.ResponseController":             "1.20",
+	"net/http.ServeFileFS":                    "1.22",
+	"net/url.JoinPath":                        "1.19",
+	"os.CopyFS":                               "1.23",
+	"os.CreateTemp":                           "1.16",
+	"os.DirEntry":                             "1.16",
+	"os.DirFS":                                "1.16",
+	"os.MkdirTemp":                            "1.16",
+	"os.ReadDir":                              "1.16",
+	"os.ReadFile":                             "1.16",
+	"os.UserConfigDir":                        "1.13",
+	"os.WriteFile":                            "1.16",
+	"os/signal.NotifyContext":                 "1.16",
+	"path/filepath.IsLocal":                   "1.20",
+	"path/filepath.Localize":                  "1.23",
+	"path/filepath.WalkDir":                   "1.16",
+	"reflect.PointerTo":                       "1.18",
+	"reflect.SliceAt":                         "1.23",
+	"reflect.TypeFor":                         "1.22",
+	"reflect.VisibleFields":                   "1.17",
+	"runtime.PanicNilError":                   "1.21",
+	"runtime.Pinner":                          "1.21",
+	"runtime/debug.BuildSetting":              "1.18",
+	"runtime/debug.CrashOptions":              "1.23",
+	"runtime/debug.ParseBuildInfo":            "1.18",
+	"runtime/debug.SetCrashOutput":            "1.23",
+	"runtime/debug.SetMemoryLimit":            "1.19",
+	"sort.Find":                               "1.19",
+	"strconv.FormatComplex":                   "1.15",
+	"strconv.ParseComplex":                    "1.15",
+	"strconv.QuotedPrefix":                    "1.17",
+	"strings.Clone":                           "1.18",
+	"strings.ContainsFunc":                    "1.21",
+	"strings.Cut":                             "1.18",
+	"strings.CutPrefix":                       "1.20",
+	"strings.CutSuffix":                       "1.20",
+	"strings.ToValidUTF8":                     "1.13",
+	"sync.OnceFunc":                           "1.21",
+	"sync.OnceValue":                          "1.21",
+	"sync.OnceValues":                         "1.21",
+	"sync/atomic.AndInt32":                    "1.23",
+	"sync/atomic.AndInt64":                    "1.23",
+	"sync/atomic.AndUint32":                   "1.23",
+	"sync/atomic.AndUint64":                   "1.23",
+	"sync/atomic.AndUintptr":                  "1.23",
+	"sync/atomic.Bool":                        "1.19",
+	"sync/atomic.Int32":                       "1.19",
+	"sync/atomic.Int64":                       "1.19",
+	"sync/atomic.OrInt32":                     "1.23",
+	"sync/atomic.OrInt64":                     "1.23",
+	"sync/atomic.OrUint32":                    "1.23",
+	"sync/atomic.OrUint64":                    "1.23",
+	"sync/atomic.OrUintptr":                   "1.23",
+	"sync/atomic.Pointer":                     "1.19",
+	"sync/atomic.Uint32":                      "1.19",
+	"sync/atomic.Uint64":                      "1.19",
+	"sync/atomic.Uintptr":                     "1.19",
+	"testing.F":                               "1.18",
+	"testing.Init":                            "1.13",
+	"testing.InternalFuzzTarget":              "1.18",
+	"testing.Testing":                         "1.21",
+	"time.UnixMicro":                          "1.17",
+	"time.UnixMilli":                          "1.17",
+	"unicode/utf8.AppendRune":                 "1.18",
+}
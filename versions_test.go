@@ -0,0 +1,58 @@
+package forklift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionUses(t *testing.T) {
+	t.Parallel()
+	var symbols []string
+	for _, use := range versionUses(loadTestdata(t, "versions")) {
+		symbols = append(symbols, use.symbol+" "+use.version)
+	}
+	assert.Equal(t, []string{
+		"slices 1.21",
+		"type parameters 1.18",
+		"any 1.18",
+		"strings.Cut 1.18",
+		"number literal prefix or separator 1.13",
+		"range over int 1.22",
+		"min 1.21",
+	}, symbols)
+}
+
+func TestDetectMinimumGoVersion(t *testing.T) {
+	t.Parallel()
+	p := loadTestdata(t, "versions")
+	v, err := DetectMinimumGoVersion(p)
+	require.NoError(t, err)
+	assert.Equal(t, "1.22", v)
+	p.Module = nil
+	p.Syntax = p.Syntax[:0]
+	v, err = DetectMinimumGoVersion(p)
+	require.NoError(t, err)
+	assert.Equal(t, "1", v)
+}
+
+func TestCheckGoVersionCompatibility(t *testing.T) {
+	t.Parallel()
+	p := loadTestdata(t, "versions")
+	errs, err := CheckGoVersionCompatibility(p, "1.20")
+	require.NoError(t, err)
+	for i := range errs {
+		errs[i].File = ""
+	}
+	assert.Equal(t, []CompatError{
+		{Symbol: "slices", IntroducedIn: "1.21", Line: 4},
+		{Symbol: "range over int", IntroducedIn: "1.22", Line: 13},
+		{Symbol: "min", IntroducedIn: "1.21", Line: 16},
+	}, errs)
+	errs, err = CheckGoVersionCompatibility(p, "go1.22.1")
+	require.NoError(t, err)
+	assert.Empty(t, errs)
+	_, err = CheckGoVersionCompatibility(p, "bad")
+	assert.Error(t, err)
+}